@@ -0,0 +1,27 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/ent/order"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/ent/orderitem"
+	"github.com/lucasHSantiago/go-select-benchmark/ent/schema"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	orderFields := schema.Order{}.Fields()
+	_ = orderFields
+	// orderDescCustomerName is the schema descriptor for customer_name field.
+	orderDescCustomerName := orderFields[1].Descriptor()
+	// order.CustomerNameValidator is a validator for the "customer_name" field. It is called by the builders before save.
+	order.CustomerNameValidator = orderDescCustomerName.Validators[0].(func(string) error)
+	orderitemFields := schema.OrderItem{}.Fields()
+	_ = orderitemFields
+	// orderitemDescProductName is the schema descriptor for product_name field.
+	orderitemDescProductName := orderitemFields[2].Descriptor()
+	// orderitem.ProductNameValidator is a validator for the "product_name" field. It is called by the builders before save.
+	orderitem.ProductNameValidator = orderitemDescProductName.Validators[0].(func(string) error)
+}
@@ -0,0 +1,12 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+var TableNames = struct {
+	OrderItems string
+	Orders     string
+}{
+	OrderItems: "order_items",
+	Orders:     "orders",
+}
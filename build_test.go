@@ -0,0 +1,248 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
+	. "github.com/go-jet/jet/v2/postgres"
+	. "github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/table"
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// orderFilter is the search a service builds per request. Zero fields leave
+// their condition out.
+type orderFilter struct {
+	customerName       string
+	minPrice, maxPrice float64
+	ids                []int32
+	limit, offset      int
+}
+
+// buildFilters are the filter shapes BenchmarkQueryBuild renders, from no
+// condition at all to every condition with a long IN list.
+var buildFilters = []struct {
+	name   string
+	filter orderFilter
+}{
+	{"None", orderFilter{}},
+	{"Name", orderFilter{customerName: "Customer 42"}},
+	{"PricePage", orderFilter{minPrice: 10, maxPrice: 500, limit: 50, offset: 100}},
+	{"All/in=10", fullFilter(10)},
+	{"All/in=100", fullFilter(100)},
+}
+
+func fullFilter(n int) orderFilter {
+	ids := make([]int32, n)
+	for i := range ids {
+		ids[i] = int32(i + 1)
+	}
+	return orderFilter{
+		customerName: "Customer 42",
+		minPrice:     10,
+		maxPrice:     500,
+		ids:          ids,
+		limit:        50,
+		offset:       100,
+	}
+}
+
+// queryBuilder renders the join restricted by f into SQL and its
+// arguments.
+type queryBuilder func(f orderFilter) (string, []any, error)
+
+// queryBuilders returns the builders BenchmarkQueryBuild compares, the
+// GORM ones rendering through db.
+func queryBuilders(db *gorm.DB) []struct {
+	name  string
+	build queryBuilder
+} {
+	return []struct {
+		name  string
+		build queryBuilder
+	}{
+		{"Jet", buildJet},
+		{"Squirrel", buildSquirrel},
+		{"Goqu", buildGoqu},
+		{"Gorm", buildGorm(db)},
+		{"GormToSQL", buildGormToSQL(db)},
+	}
+}
+
+// BenchmarkQueryBuild measures building and rendering a dynamic query per
+// request, which the contenders avoid by building their statements once.
+// It needs no database.
+func BenchmarkQueryBuild(b *testing.B) {
+	db, err := openBuildDB()
+	if err != nil {
+		b.Fatalf("failed to open gorm dry run db: %v", err)
+	}
+	builders := queryBuilders(db)
+
+	for _, fc := range buildFilters {
+		b.Run(fc.name, func(b *testing.B) {
+			for _, qb := range builders {
+				b.Run(qb.name, func(b *testing.B) {
+					b.ReportAllocs()
+					for range b.N {
+						if _, _, err := qb.build(fc.filter); err != nil {
+							b.Fatalf("build failed: %v", err)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func buildJet(f orderFilter) (string, []any, error) {
+	var conds []BoolExpression
+	if f.customerName != "" {
+		conds = append(conds, Orders.CustomerName.EQ(String(f.customerName)))
+	}
+	if f.minPrice > 0 {
+		conds = append(conds, OrderItems.Price.GT_EQ(Float(f.minPrice)))
+	}
+	if f.maxPrice > 0 {
+		conds = append(conds, OrderItems.Price.LT_EQ(Float(f.maxPrice)))
+	}
+	if len(f.ids) > 0 {
+		ids := make([]Expression, len(f.ids))
+		for i, id := range f.ids {
+			ids[i] = Int32(id)
+		}
+		conds = append(conds, Orders.ID.IN(ids...))
+	}
+
+	stmt := SELECT(
+		Orders.AllColumns,
+		OrderItems.AllColumns,
+	).FROM(
+		Orders.
+			INNER_JOIN(OrderItems, Orders.ID.EQ(OrderItems.OrderID)),
+	).ORDER_BY(
+		Orders.ID.ASC(),
+	)
+	if len(conds) > 0 {
+		stmt = stmt.WHERE(AND(conds...))
+	}
+	if f.limit > 0 {
+		stmt = stmt.LIMIT(int64(f.limit)).OFFSET(int64(f.offset))
+	}
+
+	query, args := stmt.Sql()
+	return query, args, nil
+}
+
+func buildSquirrel(f orderFilter) (string, []any, error) {
+	q := contender.SquirrelJoin()
+	if f.customerName != "" {
+		q = q.Where(sq.Eq{"orders.customer_name": f.customerName})
+	}
+	if f.minPrice > 0 {
+		q = q.Where(sq.GtOrEq{"order_items.price": f.minPrice})
+	}
+	if f.maxPrice > 0 {
+		q = q.Where(sq.LtOrEq{"order_items.price": f.maxPrice})
+	}
+	if len(f.ids) > 0 {
+		q = q.Where(sq.Eq{"orders.id": f.ids})
+	}
+	if f.limit > 0 {
+		q = q.Limit(uint64(f.limit)).Offset(uint64(f.offset))
+	}
+	return q.ToSql()
+}
+
+func buildGoqu(f orderFilter) (string, []any, error) {
+	q := contender.GoquJoin()
+	if f.customerName != "" {
+		q = q.Where(goqu.I("orders.customer_name").Eq(f.customerName))
+	}
+	if f.minPrice > 0 {
+		q = q.Where(goqu.I("order_items.price").Gte(f.minPrice))
+	}
+	if f.maxPrice > 0 {
+		q = q.Where(goqu.I("order_items.price").Lte(f.maxPrice))
+	}
+	if len(f.ids) > 0 {
+		q = q.Where(goqu.I("orders.id").In(f.ids))
+	}
+	if f.limit > 0 {
+		q = q.Limit(uint(f.limit)).Offset(uint(f.offset))
+	}
+	return q.ToSQL()
+}
+
+// openBuildDB opens a GORM handle that never connects: DryRun stops every
+// statement after it is built.
+func openBuildDB() (*gorm.DB, error) {
+	return gorm.Open(postgres.New(postgres.Config{DSN: connStr}), &gorm.Config{
+		Logger:               logger.Default.LogMode(logger.Silent),
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+}
+
+// gormSelect is contender.JoinAliases as a single GORM select list.
+var gormSelect = func() string {
+	columns := make([]string, len(contender.JoinAliases))
+	for i, c := range contender.JoinAliases {
+		columns[i] = c + ` AS "` + c + `"`
+	}
+	return strings.Join(columns, ", ")
+}()
+
+// gormFiltered adds the join restricted by f to tx and runs it.
+func gormFiltered(tx *gorm.DB, f orderFilter) *gorm.DB {
+	tx = tx.Table("orders").
+		Select(gormSelect).
+		Joins("INNER JOIN order_items ON orders.id = order_items.order_id").
+		Order("orders.id ASC")
+	if f.customerName != "" {
+		tx = tx.Where("orders.customer_name = ?", f.customerName)
+	}
+	if f.minPrice > 0 {
+		tx = tx.Where("order_items.price >= ?", f.minPrice)
+	}
+	if f.maxPrice > 0 {
+		tx = tx.Where("order_items.price <= ?", f.maxPrice)
+	}
+	if len(f.ids) > 0 {
+		tx = tx.Where("orders.id IN ?", f.ids)
+	}
+	if f.limit > 0 {
+		tx = tx.Limit(f.limit).Offset(f.offset)
+	}
+
+	var dest []map[string]any
+	return tx.Find(&dest)
+}
+
+// buildGorm renders f through a dry run session on db and returns the
+// statement's SQL and bound variables.
+func buildGorm(db *gorm.DB) queryBuilder {
+	return func(f orderFilter) (string, []any, error) {
+		tx := gormFiltered(db, f)
+		if tx.Error != nil {
+			return "", nil, tx.Error
+		}
+		return tx.Statement.SQL.String(), tx.Statement.Vars, nil
+	}
+}
+
+// buildGormToSQL renders f with ToSQL, which also interpolates the
+// variables into the SQL through the dialector's Explain.
+func buildGormToSQL(db *gorm.DB) queryBuilder {
+	return func(f orderFilter) (string, []any, error) {
+		query := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return gormFiltered(tx, f)
+		})
+		return query, nil, nil
+	}
+}
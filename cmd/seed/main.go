@@ -0,0 +1,53 @@
+// Command seed fills the benchmark database with a generated dataset.
+//
+//	go run ./cmd/seed -orders=2000000 -items=uniform:0:200 -seed=42
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-select-benchmark/dataset"
+)
+
+func main() {
+	var (
+		dsn        = flag.String("dsn", "host=localhost port=5432 user=postgres password=admin dbname=order sslmode=disable", "database connection string")
+		orders     = flag.Int("orders", 50000, "number of orders")
+		items      = flag.String("items", "fixed:5", "items per order: fixed:N, uniform:MIN:MAX or zipf:S:MAX")
+		seed       = flag.Uint64("seed", 1, "random seed")
+		nameLen    = flag.Int("customer-name-len", 0, "length of random customer names, 0 picks from a fixed list")
+		productLen = flag.Int("product-name-len", 0, "length of random product names, 0 picks from a fixed list")
+	)
+	flag.Parse()
+
+	dist, err := dataset.ParseDistribution(*items)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, *dsn)
+	if err != nil {
+		log.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	start := time.Now()
+	meta, err := dataset.Seed(ctx, conn, dataset.Config{
+		Orders:             *orders,
+		Items:              dist,
+		Seed:               *seed,
+		CustomerNameLength: *nameLen,
+		ProductNameLength:  *productLen,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("seeded %d orders (%d with items) and %d order items with %s in %s",
+		meta.Orders, meta.OrdersWithItems, meta.OrderItems, meta.Distribution, time.Since(start).Round(time.Millisecond))
+}
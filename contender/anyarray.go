@@ -0,0 +1,143 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	libpq "github.com/lib/pq"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+)
+
+func init() {
+	Register("PqAny", newPqAny)
+	Register("PgxAny", func(_ context.Context, env Env) (Contender, error) {
+		if env.Pool == nil {
+			return nil, ErrUnsupported
+		}
+		return &pgxAny{q: env.Pool}, nil
+	})
+}
+
+// pqAny loads the orders first and then their items in a second query,
+// binding the order ids as an array with pq.Array, and stitches the items
+// into their orders in Go.
+type pqAny struct {
+	db *sql.DB
+}
+
+func newPqAny(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &pqAny{db: env.DB}, nil
+}
+
+func (p *pqAny) FetchAll(ctx context.Context) ([]Order, error) {
+	return p.fetch(ctx, ordersQuery)
+}
+
+func (p *pqAny) FetchOne(ctx context.Context, id int32) (Order, error) {
+	orders, err := p.fetch(ctx, ordersQueryOne, id)
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+func (p *pqAny) fetch(ctx context.Context, query string, args ...any) ([]Order, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	f := newFolder()
+	var ids []int64
+	for rows.Next() {
+		var order model.Orders
+		if err := rows.Scan(&order.ID, &order.CustomerName, &order.CreatedAt); err != nil {
+			return nil, err
+		}
+		f.addOrder(order)
+		ids = append(ids, int64(order.ID))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	items, err := p.db.QueryContext(ctx, itemsByOrdersQuery, libpq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer items.Close()
+
+	for items.Next() {
+		var item model.OrderItems
+		if err := items.Scan(&item.ID, &item.OrderID, &item.ProductName, &item.Price, &item.Quantity); err != nil {
+			return nil, err
+		}
+		f.addItem(item)
+	}
+	return f.orders, items.Err()
+}
+
+// pgxAny is pqAny on pgx, which binds the []int32 of order ids as a
+// native Postgres array.
+type pgxAny struct {
+	q querier
+}
+
+func (p *pgxAny) FetchAll(ctx context.Context) ([]Order, error) {
+	return p.fetch(ctx, ordersQuery)
+}
+
+func (p *pgxAny) FetchOne(ctx context.Context, id int32) (Order, error) {
+	orders, err := p.fetch(ctx, ordersQueryOne, id)
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+func (p *pgxAny) fetch(ctx context.Context, query string, args ...any) ([]Order, error) {
+	rows, err := p.q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	var (
+		order model.Orders
+		ids   []int32
+	)
+	_, err = pgx.ForEachRow(rows, []any{&order.ID, &order.CustomerName, &order.CreatedAt}, func() error {
+		f.addOrder(order)
+		ids = append(ids, order.ID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	items, err := p.q.Query(ctx, itemsByOrdersQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var item model.OrderItems
+	_, err = pgx.ForEachRow(items, []any{&item.ID, &item.OrderID, &item.ProductName, &item.Price, &item.Quantity}, func() error {
+		f.addItem(item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f.orders, nil
+}
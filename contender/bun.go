@@ -0,0 +1,166 @@
+package contender
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+func init() {
+	Register("Bun", newBun)
+	Register("BunJoin", newBunJoin)
+}
+
+type bunOrderItem struct {
+	bun.BaseModel `bun:"table:order_items,alias:order_items"`
+
+	ID          int32 `bun:",pk"`
+	OrderID     *int32
+	ProductName string
+	Price       float64
+	Quantity    *int32
+}
+
+type bunOrder struct {
+	bun.BaseModel `bun:"table:orders,alias:orders"`
+
+	ID           int32 `bun:",pk"`
+	CustomerName string
+	CreatedAt    *time.Time
+	Itens        []bunOrderItem `bun:"rel:has-many,join:id=order_id"`
+}
+
+// canonical copies o into the canonical model.
+func (o *bunOrder) canonical() Order {
+	order := Order{
+		Orders: model.Orders{
+			ID:           o.ID,
+			CustomerName: o.CustomerName,
+			CreatedAt:    o.CreatedAt,
+		},
+		Itens: make([]model.OrderItems, len(o.Itens)),
+	}
+	for i, item := range o.Itens {
+		order.Itens[i] = model.OrderItems{
+			ID:          item.ID,
+			OrderID:     item.OrderID,
+			ProductName: item.ProductName,
+			Price:       item.Price,
+			Quantity:    item.Quantity,
+		}
+	}
+	return order
+}
+
+// bunContender loads bunOrder with Relation("Itens"), which like GORM's
+// Preload issues one query for the orders and one for their items.
+type bunContender struct {
+	db *bun.DB
+}
+
+func newBun(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &bunContender{db: bun.NewDB(env.DB, pgdialect.New())}, nil
+}
+
+func (b *bunContender) FetchAll(ctx context.Context) ([]Order, error) {
+	var dest []bunOrder
+	if err := b.db.NewSelect().Model(&dest).Relation("Itens").Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	orders := make([]Order, len(dest))
+	for i := range dest {
+		orders[i] = dest[i].canonical()
+	}
+	return orders, nil
+}
+
+func (b *bunContender) FetchOne(ctx context.Context, id int32) (Order, error) {
+	dest := bunOrder{ID: id}
+	if err := b.db.NewSelect().Model(&dest).WherePK().Relation("Itens").Scan(ctx); err != nil {
+		return Order{}, err
+	}
+	return dest.canonical(), nil
+}
+
+// bunJoinRow is joinRow with the column names Bun's query builder yields.
+type bunJoinRow struct {
+	ID           int32
+	CustomerName string
+	CreatedAt    *time.Time
+	OrderItemID  int32 `bun:"order_item_id"`
+	OrderID      *int32
+	ProductName  string
+	Price        float64
+	Quantity     *int32
+}
+
+// bunJoin builds the join with Bun's query builder, scans it into a flat
+// model and folds it like the hand-written contenders.
+type bunJoin struct {
+	db *bun.DB
+}
+
+func newBunJoin(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &bunJoin{db: bun.NewDB(env.DB, pgdialect.New())}, nil
+}
+
+func (b *bunJoin) query() *bun.SelectQuery {
+	return b.db.NewSelect().
+		TableExpr("orders").
+		ColumnExpr("orders.id, orders.customer_name, orders.created_at").
+		ColumnExpr("order_items.id AS order_item_id, order_items.order_id, order_items.product_name, order_items.price, order_items.quantity").
+		Join("INNER JOIN order_items ON orders.id = order_items.order_id").
+		OrderExpr("orders.id ASC")
+}
+
+func (b *bunJoin) FetchAll(ctx context.Context) ([]Order, error) {
+	var rows []bunJoinRow
+	if err := b.query().Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	for i := range rows {
+		f.add((*joinRow)(&rows[i]))
+	}
+	return f.orders, nil
+}
+
+func (b *bunJoin) FetchOne(ctx context.Context, id int32) (Order, error) {
+	var rows []bunJoinRow
+	if err := b.query().Where("orders.id = ?", id).Scan(ctx, &rows); err != nil {
+		return Order{}, err
+	}
+
+	f := newFolder()
+	for i := range rows {
+		f.add((*joinRow)(&rows[i]))
+	}
+	return firstOrder(f.orders), nil
+}
+
+func (b *bunJoin) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		rows, err := b.query().Rows(ctx)
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		defer rows.Close()
+
+		streamJoin(rows, func(row *joinRow) error {
+			return b.db.ScanRow(ctx, rows, (*bunJoinRow)(row))
+		}, yield)
+	}
+}
@@ -0,0 +1,96 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackskj/carta"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+)
+
+func init() {
+	Register("Carta", newCarta)
+}
+
+type cartaItem struct {
+	OrderItemID int32   `db:"order_items.id"`
+	OrderID     *int32  `db:"order_items.order_id"`
+	ProductName string  `db:"order_items.product_name"`
+	Price       float64 `db:"order_items.price"`
+	Quantity    *int32  `db:"order_items.quantity"`
+}
+
+type cartaOrder struct {
+	ID           int32      `db:"orders.id"`
+	CustomerName string     `db:"orders.customer_name"`
+	CreatedAt    *time.Time `db:"orders.created_at"`
+	Itens        []cartaItem
+}
+
+// canonical copies o into the canonical model. Carta only maps onto
+// tagged structs, so this copy is part of its measured cost.
+func (o *cartaOrder) canonical() Order {
+	order := Order{
+		Orders: model.Orders{
+			ID:           o.ID,
+			CustomerName: o.CustomerName,
+			CreatedAt:    o.CreatedAt,
+		},
+		Itens: make([]model.OrderItems, len(o.Itens)),
+	}
+	for i, item := range o.Itens {
+		order.Itens[i] = model.OrderItems{
+			ID:          item.OrderItemID,
+			OrderID:     item.OrderID,
+			ProductName: item.ProductName,
+			Price:       item.Price,
+			Quantity:    item.Quantity,
+		}
+	}
+	return order
+}
+
+type cartaContender struct {
+	db *sql.DB
+}
+
+func newCarta(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &cartaContender{db: env.DB}, nil
+}
+
+func (c *cartaContender) FetchAll(ctx context.Context) ([]Order, error) {
+	rows, err := c.db.QueryContext(ctx, joinQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dest []cartaOrder
+	if err := carta.Map(rows, &dest); err != nil {
+		return nil, err
+	}
+
+	orders := make([]Order, len(dest))
+	for i := range dest {
+		orders[i] = dest[i].canonical()
+	}
+	return orders, nil
+}
+
+func (c *cartaContender) FetchOne(ctx context.Context, id int32) (Order, error) {
+	rows, err := c.db.QueryContext(ctx, joinQueryOne, id)
+	if err != nil {
+		return Order{}, err
+	}
+	defer rows.Close()
+
+	var dest cartaOrder
+	if err := carta.Map(rows, &dest); err != nil {
+		return Order{}, err
+	}
+	return dest.canonical(), nil
+}
@@ -0,0 +1,167 @@
+// Package contender defines the interface every benchmarked library
+// implements and the registry the benchmark driver iterates over.
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+	"gorm.io/gorm"
+)
+
+// ErrUnsupported is returned by a Factory when the contender cannot run
+// against the given Env.
+var ErrUnsupported = errors.New("contender: unsupported")
+
+// Order is the canonical orders-with-items shape every contender returns.
+type Order struct {
+	model.Orders
+	Itens []model.OrderItems
+}
+
+// Contender fetches orders with their items through one library.
+type Contender interface {
+	// FetchAll returns every order that has items, and may also return
+	// orders without any. Orders and items may come back in any order.
+	FetchAll(ctx context.Context) ([]Order, error)
+	// FetchOne returns the order with the given id and its items.
+	FetchOne(ctx context.Context, id int32) (Order, error)
+}
+
+// Serial is implemented by contenders that must not be used by several
+// goroutines at once. Concurrent benchmarks skip them.
+type Serial interface {
+	Serial()
+}
+
+// Bounded is implemented by contenders too slow to fetch a large dataset
+// in full, such as the N+1 ones, or that cannot, such as those binding
+// every order id as its own parameter. Benchmarks skip FetchAll when the
+// dataset has more than MaxOrders orders; zero means no limit.
+type Bounded interface {
+	MaxOrders() int
+}
+
+// Streamer is implemented by contenders that can yield each order as soon
+// as its last join row is read, without holding the whole result. Stream
+// yields the orders FetchAll returns that have items, in order id order;
+// after an error it yields nothing more.
+type Streamer interface {
+	Stream(ctx context.Context) iter.Seq2[Order, error]
+}
+
+// Cursor is implemented by contenders that can read the join through a
+// server-side cursor, declared in a read-only transaction and fetched size
+// rows at a time. An order's items may be split across two fetches.
+type Cursor interface {
+	FetchAllCursor(ctx context.Context, size int) ([]Order, error)
+}
+
+// Explainer is implemented by contenders whose FetchAll is one statement,
+// so the server's share of it can be measured alone. ExplainAll runs the
+// statement under EXPLAIN ANALYZE and returns the execution time Postgres
+// reports, which leaves out sending the rows.
+type Explainer interface {
+	ExplainAll(ctx context.Context) (time.Duration, error)
+}
+
+// Floor reads the join without mapping it: the driver receives every row
+// and the values are left undecoded. Its cost is the database's and the
+// driver's, which every contender pays before its library adds its own.
+// Floors are registered apart from the contenders since they return no
+// orders, only how many rows they read.
+type Floor interface {
+	DrainAll(ctx context.Context) (rows int, err error)
+	DrainOne(ctx context.Context, id int32) (rows int, err error)
+}
+
+// Env holds the connections shared by every contender.
+type Env struct {
+	// DB is a database/sql handle opened with lib/pq.
+	DB *sql.DB
+	// Pool is a native pgx pool.
+	Pool *pgxpool.Pool
+	// DSN is the key=value connection string, for libraries that open
+	// their own connections.
+	DSN string
+	// GormDialector, when set, is used by GORM instead of opening its own
+	// connection from DSN.
+	GormDialector gorm.Dialector
+	// PoolConfig, when set, is applied to every pool a contender opens
+	// itself. DB and Pool are expected to be opened with it already.
+	PoolConfig *PoolConfig
+}
+
+// Factory builds a contender for the given Env. A contender that holds
+// its own connections may also implement io.Closer.
+type Factory func(ctx context.Context, env Env) (Contender, error)
+
+var registry = map[string]Factory{}
+
+// Register makes a contender available under name. It panics if name is
+// registered twice.
+func Register(name string, f Factory) {
+	if _, ok := registry[name]; ok {
+		panic("contender: Register called twice for " + name)
+	}
+	registry[name] = f
+}
+
+// Names returns the registered contender names in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FloorFactory builds a floor for the given Env.
+type FloorFactory func(ctx context.Context, env Env) (Floor, error)
+
+var floors = map[string]FloorFactory{}
+
+// RegisterFloor makes a floor available under name. It panics if name is
+// registered twice.
+func RegisterFloor(name string, f FloorFactory) {
+	if _, ok := floors[name]; ok {
+		panic("contender: RegisterFloor called twice for " + name)
+	}
+	floors[name] = f
+}
+
+// FloorNames returns the registered floor names in sorted order.
+func FloorNames() []string {
+	names := make([]string, 0, len(floors))
+	for name := range floors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewFloor builds the floor registered under name.
+func NewFloor(ctx context.Context, name string, env Env) (Floor, error) {
+	f, ok := floors[name]
+	if !ok {
+		return nil, fmt.Errorf("contender: unknown floor %q", name)
+	}
+	return f(ctx, env)
+}
+
+// New builds the contender registered under name.
+func New(ctx context.Context, name string, env Env) (Contender, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("contender: unknown contender %q", name)
+	}
+	return f(ctx, env)
+}
@@ -0,0 +1,80 @@
+package contender
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/ent"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/ent/order"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+)
+
+func init() {
+	Register("Ent", newEnt)
+}
+
+// entContender eager-loads the items edge with WithItems, which issues
+// one query for the orders and one for their items.
+type entContender struct {
+	client *ent.Client
+}
+
+// MaxOrders bounds ent to what its items query can bind: WithItems puts
+// every order id in an IN list, one parameter each.
+func (*entContender) MaxOrders() int {
+	return maxBindParams
+}
+
+func newEnt(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+
+	drv := entsql.OpenDB(dialect.Postgres, env.DB)
+	return &entContender{client: ent.NewClient(ent.Driver(drv))}, nil
+}
+
+func (e *entContender) FetchAll(ctx context.Context) ([]Order, error) {
+	dest, err := e.client.Order.Query().WithItems().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]Order, len(dest))
+	for i, o := range dest {
+		orders[i] = entCanonical(o)
+	}
+	return orders, nil
+}
+
+func (e *entContender) FetchOne(ctx context.Context, id int32) (Order, error) {
+	dest, err := e.client.Order.Query().Where(order.ID(id)).WithItems().Only(ctx)
+	if err != nil {
+		return Order{}, err
+	}
+	return entCanonical(dest), nil
+}
+
+// entCanonical copies an ent order and its loaded items into the
+// canonical model.
+func entCanonical(o *ent.Order) Order {
+	canonical := Order{
+		Orders: model.Orders{
+			ID:           o.ID,
+			CustomerName: o.CustomerName,
+			CreatedAt:    o.CreatedAt,
+		},
+		Itens: make([]model.OrderItems, len(o.Edges.Items)),
+	}
+	for i, item := range o.Edges.Items {
+		canonical.Itens[i] = model.OrderItems{
+			ID:          item.ID,
+			OrderID:     item.OrderID,
+			ProductName: item.ProductName,
+			Price:       item.Price,
+			Quantity:    item.Quantity,
+		}
+	}
+	return canonical
+}
@@ -0,0 +1,94 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func init() {
+	RegisterFloor("PgxRaw", func(_ context.Context, env Env) (Floor, error) {
+		if env.Pool == nil {
+			return nil, ErrUnsupported
+		}
+		return &pgxRaw{pool: env.Pool}, nil
+	})
+	RegisterFloor("PqRaw", func(_ context.Context, env Env) (Floor, error) {
+		if env.DB == nil {
+			return nil, ErrUnsupported
+		}
+		return &pqRaw{db: env.DB}, nil
+	})
+}
+
+// pgxRaw sends the join through pgconn and steps its ResultReader over
+// every row without looking at the values, the least reading the rows can
+// cost.
+type pgxRaw struct {
+	pool *pgxpool.Pool
+}
+
+func (p *pgxRaw) DrainAll(ctx context.Context) (int, error) {
+	return p.drain(ctx, joinQuery, nil)
+}
+
+func (p *pgxRaw) DrainOne(ctx context.Context, id int32) (int, error) {
+	// With no parameter OIDs the id is sent as text and typed by Postgres.
+	return p.drain(ctx, joinQueryOne, [][]byte{strconv.AppendInt(nil, int64(id), 10)})
+}
+
+func (p *pgxRaw) drain(ctx context.Context, sql string, params [][]byte) (int, error) {
+	conn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	rr := conn.Conn().PgConn().ExecParams(ctx, sql, params, nil, nil, nil)
+	n := 0
+	for rr.NextRow() {
+		n++
+	}
+	_, err = rr.Close()
+	return n, err
+}
+
+// pqRaw scans the join into sql.RawBytes, which database/sql fills with
+// the driver's buffers without converting them, so only database/sql and
+// lib/pq are paid for.
+type pqRaw struct {
+	db *sql.DB
+}
+
+func (p *pqRaw) DrainAll(ctx context.Context) (int, error) {
+	return p.drain(ctx, joinQuery)
+}
+
+func (p *pqRaw) DrainOne(ctx context.Context, id int32) (int, error) {
+	return p.drain(ctx, joinQueryOne, id)
+}
+
+func (p *pqRaw) drain(ctx context.Context, query string, args ...any) (int, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var values [8]sql.RawBytes
+	dest := make([]any, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
@@ -0,0 +1,395 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func init() {
+	strategies := []struct {
+		name   string
+		fetch  gormStrategy
+		stream gormStream
+	}{
+		{"", gormPreload, nil},
+		{"PreloadCond", gormPreloadCond, nil},
+		{"Joins", gormJoins, nil},
+		{"Raw", gormRaw, nil},
+		{"Rows", gormRows, gormRowsStream},
+		{"Batches100", gormBatches(100), nil},
+		{"Batches1000", gormBatches(1000), nil},
+		{"Batches10000", gormBatches(10000), nil},
+	}
+	for _, s := range strategies {
+		Register("Gorm"+s.name, newGorm(gormVariant{fetch: s.fetch, stream: s.stream}))
+		Register("Gorm"+s.name+"Prepared", newGorm(gormVariant{fetch: s.fetch, stream: s.stream, prepared: true}))
+	}
+	// GORM only opens its default transaction around writes, so one probe
+	// is enough to show that skipping it changes nothing for a read.
+	Register("GormSkipTx", newGorm(gormVariant{fetch: gormPreload, skipTx: true}))
+	Register("GormNPlusOne", newGorm(gormVariant{fetch: gormNPlusOne, maxOrders: nPlusOneMaxOrders}))
+}
+
+type OrderItem struct {
+	ID          int32   `gorm:"column:id"`
+	OrderID     *int32  `gorm:"column:order_id"`
+	ProductName string  `gorm:"column:product_name"`
+	Price       float64 `gorm:"column:price"`
+	Quantity    *int32  `gorm:"column:quantity"`
+}
+
+type OrderWithItems struct {
+	ID           int32       `gorm:"column:id"`
+	CustomerName string      `gorm:"column:customer_name"`
+	CreatedAt    *time.Time  `gorm:"column:created_at"`
+	Itens        []OrderItem `gorm:"foreignKey:OrderID;references:ID"`
+}
+
+func (OrderWithItems) TableName() string {
+	return "orders"
+}
+
+// canonical copies o into the canonical model.
+func (o *OrderWithItems) canonical() Order {
+	order := Order{
+		Orders: model.Orders{
+			ID:           o.ID,
+			CustomerName: o.CustomerName,
+			CreatedAt:    o.CreatedAt,
+		},
+		Itens: make([]model.OrderItems, len(o.Itens)),
+	}
+	for i, item := range o.Itens {
+		order.Itens[i] = model.OrderItems(item)
+	}
+	return order
+}
+
+// gormStrategy is one GORM idiom for loading orders with their items. It
+// loads every order when id is nil and only the order with that id
+// otherwise.
+type gormStrategy func(db *gorm.DB, id *int32) ([]Order, error)
+
+// gormPreload loads orders and their items in two queries with Preload.
+func gormPreload(db *gorm.DB, id *int32) ([]Order, error) {
+	db = db.Preload("Itens")
+	if id != nil {
+		var dest OrderWithItems
+		if err := db.First(&dest, "id = ?", *id).Error; err != nil {
+			return nil, err
+		}
+		return []Order{dest.canonical()}, nil
+	}
+
+	var dest []OrderWithItems
+	if err := db.Find(&dest).Error; err != nil {
+		return nil, err
+	}
+	return gormCanonical(dest), nil
+}
+
+// gormPreloadCond is gormPreload with a condition on the items query, which
+// keeps every item but makes GORM bind an argument and add an ORDER BY.
+func gormPreloadCond(db *gorm.DB, id *int32) ([]Order, error) {
+	var dest []OrderWithItems
+	db = db.Preload("Itens", func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("price >= ?", 0).Order("id")
+	})
+	if id != nil {
+		db = db.Where("id = ?", *id)
+	}
+	if err := db.Find(&dest).Error; err != nil {
+		return nil, err
+	}
+	return gormCanonical(dest), nil
+}
+
+// gormJoinRow is joinRow with column tags GORM understands.
+type gormJoinRow struct {
+	ID           int32      `gorm:"column:orders.id"`
+	CustomerName string     `gorm:"column:orders.customer_name"`
+	CreatedAt    *time.Time `gorm:"column:orders.created_at"`
+	OrderItemID  int32      `gorm:"column:order_items.id"`
+	OrderID      *int32     `gorm:"column:order_items.order_id"`
+	ProductName  string     `gorm:"column:order_items.product_name"`
+	Price        float64    `gorm:"column:order_items.price"`
+	Quantity     *int32     `gorm:"column:order_items.quantity"`
+}
+
+// gormJoinQueryOne is joinQueryOne with GORM's placeholder.
+const gormJoinQueryOne = joinColumns + `
+	WHERE orders.id = ?
+	ORDER BY orders.id ASC;
+`
+
+// gormJoinSelect is the select list of joinColumns for the query builder.
+const gormJoinSelect = `orders.id AS "orders.id", orders.customer_name AS "orders.customer_name", ` +
+	`orders.created_at AS "orders.created_at", order_items.id AS "order_items.id", ` +
+	`order_items.order_id AS "order_items.order_id", order_items.product_name AS "order_items.product_name", ` +
+	`order_items.price AS "order_items.price", order_items.quantity AS "order_items.quantity"`
+
+// gormJoins builds the join with the query builder's Joins and scans it
+// into flat rows, since Joins only preloads has-one and belongs-to
+// relations and cannot load Itens.
+func gormJoins(db *gorm.DB, id *int32) ([]Order, error) {
+	db = db.Table("orders").
+		Select(gormJoinSelect).
+		Joins("INNER JOIN order_items ON orders.id = order_items.order_id").
+		Order("orders.id ASC")
+	if id != nil {
+		db = db.Where("orders.id = ?", *id)
+	}
+
+	var rows []gormJoinRow
+	if err := db.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	for i := range rows {
+		f.add((*joinRow)(&rows[i]))
+	}
+	return f.orders, nil
+}
+
+// gormRawQuery returns db running joinQuery, or gormJoinQueryOne when id is
+// set.
+func gormRawQuery(db *gorm.DB, id *int32) *gorm.DB {
+	if id != nil {
+		return db.Raw(gormJoinQueryOne, *id)
+	}
+	return db.Raw(joinQuery)
+}
+
+// gormRaw scans the whole join into flat rows with Scan and folds them.
+func gormRaw(db *gorm.DB, id *int32) ([]Order, error) {
+	var rows []gormJoinRow
+	if err := gormRawQuery(db, id).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	for i := range rows {
+		f.add((*joinRow)(&rows[i]))
+	}
+	return f.orders, nil
+}
+
+// gormRows streams the join with Rows and folds each row as ScanRows
+// decodes it, so the flat rows are never held all at once.
+func gormRows(db *gorm.DB, id *int32) ([]Order, error) {
+	rows, err := gormRawQuery(db, id).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	f := newFolder()
+	for rows.Next() {
+		var row gormJoinRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return nil, err
+		}
+		f.add((*joinRow)(&row))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return f.orders, nil
+}
+
+// gormStream streams every order the way Streamer does.
+type gormStream func(db *gorm.DB) iter.Seq2[Order, error]
+
+// gormRowsStream is gormRows yielding each order once its rows are read.
+func gormRowsStream(db *gorm.DB) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		rows, err := db.Raw(joinQuery).Rows()
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		defer rows.Close()
+
+		streamJoin(rows, func(row *joinRow) error {
+			return db.ScanRows(rows, (*gormJoinRow)(row))
+		}, yield)
+	}
+}
+
+// gormBatches returns a strategy that walks the orders with FindInBatches,
+// preloading the items of each batch of size orders.
+func gormBatches(size int) gormStrategy {
+	return func(db *gorm.DB, id *int32) ([]Order, error) {
+		var (
+			batch  []OrderWithItems
+			orders []Order
+		)
+		db = db.Preload("Itens")
+		if id != nil {
+			db = db.Where("id = ?", *id)
+		}
+		err := db.FindInBatches(&batch, size, func(*gorm.DB, int) error {
+			for i := range batch {
+				orders = append(orders, batch[i].canonical())
+			}
+			return nil
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+		return orders, nil
+	}
+}
+
+// gormNPlusOne loads the orders without Preload and then the items of
+// each order in its own query, the code that results from forgetting
+// Preload.
+func gormNPlusOne(db *gorm.DB, id *int32) ([]Order, error) {
+	var dest []OrderWithItems
+	query := db
+	if id != nil {
+		query = query.Where("id = ?", *id)
+	}
+	if err := query.Find(&dest).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range dest {
+		if err := db.Where("order_id = ?", dest[i].ID).Find(&dest[i].Itens).Error; err != nil {
+			return nil, err
+		}
+	}
+	return gormCanonical(dest), nil
+}
+
+func gormCanonical(dest []OrderWithItems) []Order {
+	orders := make([]Order, len(dest))
+	for i := range dest {
+		orders[i] = dest[i].canonical()
+	}
+	return orders
+}
+
+type gormContender struct {
+	db    *gorm.DB
+	fetch gormStrategy
+	// ownsConn reports whether db opened its own connection pool, as
+	// opposed to sharing one through Env.GormDialector.
+	ownsConn bool
+	// maxOrders is the MaxOrders of a strategy too slow for large
+	// datasets, or zero.
+	maxOrders int
+}
+
+// gormStreamer is a gormContender whose strategy can also stream.
+type gormStreamer struct {
+	*gormContender
+	stream gormStream
+}
+
+func (g *gormStreamer) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return g.stream(g.db.WithContext(ctx))
+}
+
+// gormVariant is one GORM contender: a strategy and how GORM is opened
+// for it.
+type gormVariant struct {
+	fetch gormStrategy
+	// stream, when set, makes the contender a Streamer.
+	stream gormStream
+	// prepared caches prepared statements, as PrepareStmt does.
+	prepared bool
+	// skipTx skips the transaction GORM otherwise opens around writes, as
+	// SkipDefaultTransaction does. Reads never open one, so it is
+	// registered to show that it does not change them.
+	skipTx bool
+	// maxOrders bounds the contender as in Bounded.
+	maxOrders int
+}
+
+func newGorm(v gormVariant) Factory {
+	return func(_ context.Context, env Env) (Contender, error) {
+		dialector := env.GormDialector
+		if dialector == nil {
+			if env.DSN == "" {
+				return nil, ErrUnsupported
+			}
+			dialector = postgres.New(postgres.Config{
+				DSN:                  env.DSN,
+				PreferSimpleProtocol: true,
+			})
+		}
+
+		db, err := gorm.Open(dialector, &gorm.Config{
+			Logger:                 logger.Default.LogMode(logger.Silent),
+			PrepareStmt:            v.prepared,
+			SkipDefaultTransaction: v.skipTx,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		g := &gormContender{db: db, fetch: v.fetch, ownsConn: env.GormDialector == nil, maxOrders: v.maxOrders}
+		if g.ownsConn && env.PoolConfig != nil {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return nil, err
+			}
+			env.PoolConfig.ApplyDB(sqlDB)
+		}
+		if v.stream != nil {
+			return &gormStreamer{gormContender: g, stream: v.stream}, nil
+		}
+		return g, nil
+	}
+}
+
+func (g *gormContender) FetchAll(ctx context.Context) ([]Order, error) {
+	return g.fetch(g.db.WithContext(ctx), nil)
+}
+
+func (g *gormContender) FetchOne(ctx context.Context, id int32) (Order, error) {
+	orders, err := g.fetch(g.db.WithContext(ctx), &id)
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+func (g *gormContender) MaxOrders() int {
+	return g.maxOrders
+}
+
+// DBStats returns the statistics of the pool GORM opened itself. They are
+// empty when the pool is shared through Env.GormDialector.
+func (g *gormContender) DBStats() sql.DBStats {
+	if !g.ownsConn {
+		return sql.DBStats{}
+	}
+
+	db, err := g.db.DB()
+	if err != nil {
+		return sql.DBStats{}
+	}
+	return db.Stats()
+}
+
+func (g *gormContender) Close() error {
+	if !g.ownsConn {
+		return nil
+	}
+
+	db, err := g.db.DB()
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
@@ -0,0 +1,119 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+	. "github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/table"
+)
+
+func init() {
+	Register("Jet", newJet)
+}
+
+type jet struct {
+	env Env
+	all SelectStatement
+}
+
+func newJet(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+
+	all := SELECT(
+		Orders.AllColumns,
+		OrderItems.AllColumns,
+	).FROM(
+		Orders.
+			INNER_JOIN(OrderItems, Orders.ID.EQ(OrderItems.OrderID)),
+	).ORDER_BY(Orders.ID.ASC())
+
+	return &jet{env: env, all: all}, nil
+}
+
+func (j *jet) FetchAll(ctx context.Context) ([]Order, error) {
+	var dest []Order
+	if err := j.all.QueryContext(ctx, j.env.DB, &dest); err != nil {
+		return nil, err
+	}
+	return dest, nil
+}
+
+func (j *jet) FetchOne(ctx context.Context, id int32) (Order, error) {
+	stmt := SELECT(
+		Orders.AllColumns,
+		OrderItems.AllColumns,
+	).FROM(
+		Orders.
+			INNER_JOIN(OrderItems, Orders.ID.EQ(OrderItems.OrderID)),
+	).WHERE(
+		Orders.ID.EQ(Int32(id)),
+	).ORDER_BY(
+		Orders.ID.ASC(),
+	)
+
+	var dest Order
+	if err := stmt.QueryContext(ctx, j.env.DB, &dest); err != nil {
+		return Order{}, err
+	}
+	return dest, nil
+}
+
+// Stream reads the join with Jet's Rows, which maps one row at a time
+// into the generated models.
+func (j *jet) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		rows, err := j.all.Rows(ctx, j.env.DB)
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		defer rows.Close()
+
+		streamPairs(rows, func() (model.Orders, model.OrderItems, error) {
+			var row struct {
+				model.Orders
+				model.OrderItems
+			}
+			err := rows.Scan(&row)
+			return row.Orders, row.OrderItems, err
+		}, yield)
+	}
+}
+
+// FetchAllCursor declares the cursor over the SQL Jet renders and maps
+// every FETCH with qrm.Query. Jet groups each FETCH on its own, so an
+// order split by a FETCH boundary is merged afterwards.
+func (j *jet) FetchAllCursor(ctx context.Context, size int) ([]Order, error) {
+	tx, err := j.env.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query, args := j.all.Sql()
+	if _, err := tx.ExecContext(ctx, "DECLARE "+joinCursor+" NO SCROLL CURSOR FOR "+query, args...); err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	fetch := fetchJoinCursor(size)
+	err = drainCursor(size, func() (int, error) {
+		var chunk []Order
+		n, err := qrm.Query(ctx, tx, fetch, nil, &chunk)
+		if err != nil {
+			return 0, err
+		}
+		orders = appendChunk(orders, chunk)
+		return int(n), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, tx.Commit()
+}
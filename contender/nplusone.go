@@ -0,0 +1,96 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+)
+
+func init() {
+	Register("PqNPlusOne", newPqNPlusOne)
+}
+
+// nPlusOneMaxOrders bounds the N+1 contenders, which send one query per
+// order.
+const nPlusOneMaxOrders = 1000
+
+// pqNPlusOne loads the orders and then the items of each order in its own
+// query. It is the anti-pattern the other contenders are measured
+// against, not a strategy to copy.
+type pqNPlusOne struct {
+	db *sql.DB
+}
+
+func newPqNPlusOne(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &pqNPlusOne{db: env.DB}, nil
+}
+
+func (*pqNPlusOne) MaxOrders() int {
+	return nPlusOneMaxOrders
+}
+
+func (p *pqNPlusOne) FetchAll(ctx context.Context) ([]Order, error) {
+	return p.fetch(ctx, ordersQuery)
+}
+
+func (p *pqNPlusOne) FetchOne(ctx context.Context, id int32) (Order, error) {
+	orders, err := p.fetch(ctx, ordersQueryOne, id)
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+func (p *pqNPlusOne) fetch(ctx context.Context, query string, args ...any) ([]Order, error) {
+	orders, err := p.orders(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range orders {
+		if orders[i].Itens, err = p.items(ctx, orders[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+func (p *pqNPlusOne) orders(ctx context.Context, query string, args ...any) ([]Order, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []Order
+	for rows.Next() {
+		var order Order
+		if err := rows.Scan(&order.ID, &order.CustomerName, &order.CreatedAt); err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func (p *pqNPlusOne) items(ctx context.Context, orderID int32) ([]model.OrderItems, error) {
+	rows, err := p.db.QueryContext(ctx, itemsByOrderQuery, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.OrderItems
+	for rows.Next() {
+		var item model.OrderItems
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.ProductName, &item.Price, &item.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,144 @@
+package contender
+
+import (
+	"context"
+	"iter"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func init() {
+	Register("Pgx", func(_ context.Context, env Env) (Contender, error) {
+		if env.Pool == nil {
+			return nil, ErrUnsupported
+		}
+		return &pgxContender{q: env.Pool}, nil
+	})
+	Register("PgxBinary", func(_ context.Context, env Env) (Contender, error) {
+		if env.Pool == nil {
+			return nil, ErrUnsupported
+		}
+		return &pgxContender{q: env.Pool, opts: []any{pgx.QueryResultFormats{pgx.BinaryFormatCode}}}, nil
+	})
+	Register("PgxConn", newPgxConn)
+}
+
+// querier is implemented by both *pgx.Conn and *pgxpool.Pool.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// pgxContender collects the join with pgx.CollectRows and
+// RowToStructByName. opts are passed ahead of the query arguments, which
+// is how pgx takes per-query options such as the result format.
+type pgxContender struct {
+	q    querier
+	opts []any
+}
+
+// newPgxConn runs on a single pgx.Conn, leaving pgxpool's acquire and
+// release out of the measurement. It is not safe for concurrent use.
+func newPgxConn(ctx context.Context, env Env) (Contender, error) {
+	if env.DSN == "" {
+		return nil, ErrUnsupported
+	}
+
+	conn, err := pgx.Connect(ctx, env.DSN)
+	if err != nil {
+		return nil, err
+	}
+	return &pgxConnContender{pgxContender{q: conn}}, nil
+}
+
+// pgxConnContender is a pgxContender that owns a single *pgx.Conn.
+type pgxConnContender struct {
+	pgxContender
+}
+
+func (*pgxConnContender) Serial() {}
+
+func (p *pgxConnContender) Close() error {
+	return p.q.(*pgx.Conn).Close(context.Background())
+}
+
+func (p *pgxContender) FetchAll(ctx context.Context) ([]Order, error) {
+	rows, err := p.q.Query(ctx, joinQuery, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := pgx.CollectRows(rows, pgx.RowToStructByName[joinRow])
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	for i := range results {
+		f.add(&results[i])
+	}
+	return f.orders, nil
+}
+
+func (p *pgxContender) FetchOne(ctx context.Context, id int32) (Order, error) {
+	rows, err := p.q.Query(ctx, joinQueryOne, append(p.opts[:len(p.opts):len(p.opts)], id)...)
+	if err != nil {
+		return Order{}, err
+	}
+
+	results, err := pgx.CollectRows(rows, pgx.RowToStructByName[joinRow])
+	if err != nil {
+		return Order{}, err
+	}
+	return foldOne(results), nil
+}
+
+func (p *pgxContender) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		rows, err := p.q.Query(ctx, joinQuery, p.opts...)
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		defer rows.Close()
+
+		streamJoin(rows, func(row *joinRow) (err error) {
+			*row, err = pgx.RowToStructByName[joinRow](rows)
+			return err
+		}, yield)
+	}
+}
+
+func (p *pgxContender) FetchAllCursor(ctx context.Context, size int) ([]Order, error) {
+	tx, err := p.q.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, declareJoinCursor); err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	fetch := fetchJoinCursor(size)
+	err = drainCursor(size, func() (int, error) {
+		rows, err := tx.Query(ctx, fetch, p.opts...)
+		if err != nil {
+			return 0, err
+		}
+
+		results, err := pgx.CollectRows(rows, pgx.RowToStructByName[joinRow])
+		if err != nil {
+			return 0, err
+		}
+		for i := range results {
+			f.add(&results[i])
+		}
+		return len(results), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f.orders, tx.Commit(ctx)
+}
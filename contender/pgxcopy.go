@@ -0,0 +1,263 @@
+package contender
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func init() {
+	Register("PgxCopy", func(_ context.Context, env Env) (Contender, error) {
+		if env.Pool == nil {
+			return nil, ErrUnsupported
+		}
+		return &pgxCopy{pool: env.Pool}, nil
+	})
+}
+
+// copyJoin wraps the join in a binary COPY. COPY takes no parameters, so
+// copyJoinOne inlines the order id.
+const copyJoin = "COPY (" + joinColumns + "ORDER BY orders.id ASC) TO STDOUT WITH (FORMAT binary)"
+
+func copyJoinOne(id int32) string {
+	return "COPY (" + joinColumns + "WHERE orders.id = " + strconv.Itoa(int(id)) + " ORDER BY orders.id ASC) TO STDOUT WITH (FORMAT binary)"
+}
+
+// pgxCopy reads the join with COPY ... TO STDOUT in the binary format
+// through pgconn and decodes the tuples by hand. With no driver type
+// mapping, no reflection and no per-row statement, it is the ceiling the
+// other contenders are measured against. It folds the rows like the
+// hand-written loops, so the difference is only in reading and decoding.
+type pgxCopy struct {
+	pool *pgxpool.Pool
+}
+
+func (p *pgxCopy) FetchAll(ctx context.Context) ([]Order, error) {
+	return p.copy(ctx, copyJoin)
+}
+
+func (p *pgxCopy) FetchOne(ctx context.Context, id int32) (Order, error) {
+	orders, err := p.copy(ctx, copyJoinOne(id))
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+func (p *pgxCopy) copy(ctx context.Context, sql string) ([]Order, error) {
+	conn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	d := &copyDecoder{f: newFolder()}
+	if _, err := conn.Conn().PgConn().CopyTo(ctx, d, sql); err != nil {
+		return nil, err
+	}
+	if !d.done {
+		return nil, errors.New("pgxcopy: missing trailer")
+	}
+	return d.f.orders, nil
+}
+
+// copyDecoder is the io.Writer pgconn copies the binary COPY stream into.
+// It decodes every complete tuple of the join as it arrives and carries an
+// incomplete one over to the next write.
+type copyDecoder struct {
+	f *folder
+	// pending holds the start of a tuple split across writes.
+	pending []byte
+	header  bool
+	done    bool
+}
+
+func (d *copyDecoder) Write(p []byte) (int, error) {
+	data := p
+	if len(d.pending) > 0 {
+		d.pending = append(d.pending, p...)
+		data = d.pending
+	}
+
+	n, err := d.decode(data)
+	if err != nil {
+		return 0, err
+	}
+	d.pending = append(d.pending[:0], data[n:]...)
+	return len(p), nil
+}
+
+// copySignature starts every binary COPY stream.
+var copySignature = []byte("PGCOPY\n\xff\r\n\x00")
+
+// decode decodes the complete parts of data and returns how many bytes it
+// consumed.
+func (d *copyDecoder) decode(data []byte) (int, error) {
+	pos := 0
+	if !d.header {
+		// Signature, flags and the header extension length, then the
+		// extension itself.
+		const fixed = 11 + 4 + 4
+		if len(data) < fixed {
+			return 0, nil
+		}
+		if !bytes.Equal(data[:11], copySignature) {
+			return 0, errors.New("pgxcopy: bad signature")
+		}
+		ext := int(binary.BigEndian.Uint32(data[15:]))
+		if len(data) < fixed+ext {
+			return 0, nil
+		}
+		pos = fixed + ext
+		d.header = true
+	}
+
+	for !d.done {
+		n, err := d.tuple(data[pos:])
+		if err != nil || n == 0 {
+			return pos, err
+		}
+		pos += n
+	}
+	return pos, nil
+}
+
+// tuple decodes one tuple from the start of data and returns its length,
+// or 0 when data does not hold all of it yet.
+func (d *copyDecoder) tuple(data []byte) (int, error) {
+	if len(data) < 2 {
+		return 0, nil
+	}
+	fields := int16(binary.BigEndian.Uint16(data))
+	if fields == -1 {
+		d.done = true
+		return 2, nil
+	}
+	if fields != 8 {
+		return 0, fmt.Errorf("pgxcopy: expected 8 fields, got %d", fields)
+	}
+
+	// Find every field first, so a split tuple is decoded only once.
+	var values [8][]byte
+	pos := 2
+	for i := range values {
+		if len(data) < pos+4 {
+			return 0, nil
+		}
+		size := int32(binary.BigEndian.Uint32(data[pos:]))
+		pos += 4
+		if size < 0 {
+			continue
+		}
+		if len(data) < pos+int(size) {
+			return 0, nil
+		}
+		values[i] = data[pos : pos+int(size)]
+		pos += int(size)
+	}
+
+	var (
+		row joinRow
+		err error
+	)
+	row.ID = copyInt4(values[0])
+	row.CustomerName = string(values[1])
+	if values[2] != nil {
+		if row.CreatedAt, err = copyTimestamp(values[2]); err != nil {
+			return 0, err
+		}
+	}
+	row.OrderItemID = copyInt4(values[3])
+	if values[4] != nil {
+		id := copyInt4(values[4])
+		row.OrderID = &id
+	}
+	row.ProductName = string(values[5])
+	if row.Price, err = copyNumeric(values[6]); err != nil {
+		return 0, err
+	}
+	if values[7] != nil {
+		quantity := copyInt4(values[7])
+		row.Quantity = &quantity
+	}
+
+	d.f.add(&row)
+	return pos, nil
+}
+
+func copyInt4(b []byte) int32 {
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+// postgresEpoch is the zero of binary timestamps, in microseconds.
+var postgresEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func copyTimestamp(b []byte) (*time.Time, error) {
+	micros := int64(binary.BigEndian.Uint64(b))
+	if micros == math.MaxInt64 || micros == math.MinInt64 {
+		return nil, errors.New("pgxcopy: infinite timestamp")
+	}
+	t := postgresEpoch.Add(time.Duration(micros) * time.Microsecond)
+	return &t, nil
+}
+
+// pow10 holds the powers of ten float64 represents exactly.
+var pow10 = [...]float64{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22}
+
+// copyNumeric decodes a binary numeric: its digit count, weight, sign and
+// display scale, then base-10000 digits. The digits are gathered into an
+// integer at the display scale and divided once by an exact power of ten.
+// While that integer fits in 2^53 it converts exactly, so the result is the
+// float64 nearest the decimal, as parsing its text would give; past that
+// the conversion rounds first and the result may be off by an ulp. NaN,
+// the infinities and values that overflow the integer are errors rather
+// than a wrong number.
+func copyNumeric(b []byte) (float64, error) {
+	if len(b) < 8 {
+		return 0, errors.New("pgxcopy: short numeric")
+	}
+	ndigits := int(int16(binary.BigEndian.Uint16(b)))
+	weight := int(int16(binary.BigEndian.Uint16(b[2:])))
+	sign := binary.BigEndian.Uint16(b[4:])
+	dscale := int(int16(binary.BigEndian.Uint16(b[6:])))
+	// 0xc000 is NaN, 0xd000 and 0xf000 are +Infinity and -Infinity.
+	if sign != 0 && sign != 0x4000 {
+		return 0, fmt.Errorf("pgxcopy: unsupported numeric sign %#x", sign)
+	}
+	// More than 4 base-10000 digits may not fit the uint64 below.
+	if ndigits > 4 || len(b) < 8+2*ndigits || dscale >= len(pow10) {
+		return 0, errors.New("pgxcopy: unsupported numeric")
+	}
+	if ndigits == 0 {
+		return 0, nil
+	}
+
+	var n uint64
+	for i := range ndigits {
+		n = n*10000 + uint64(binary.BigEndian.Uint16(b[8+2*i:]))
+	}
+	// n is the value times 10^scale.
+	scale := 4 * (ndigits - 1 - weight)
+	for ; scale > dscale; scale-- {
+		n /= 10
+	}
+	for ; scale < dscale; scale++ {
+		if n > math.MaxUint64/10 {
+			return 0, errors.New("pgxcopy: numeric out of range")
+		}
+		n *= 10
+	}
+
+	v := float64(n) / pow10[dscale]
+	if sign == 0x4000 {
+		v = -v
+	}
+	return v, nil
+}
@@ -0,0 +1,45 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+func init() {
+	Register("PgxStdlib", newPgxStdlib)
+}
+
+// pgxStdlib runs the pq scan loop on pgx's database/sql driver. Next to
+// Pq it isolates the driver, and next to Pgx it isolates database/sql's
+// pool from pgxpool.
+type pgxStdlib struct {
+	pq
+}
+
+func newPgxStdlib(_ context.Context, env Env) (Contender, error) {
+	if env.DSN == "" {
+		return nil, ErrUnsupported
+	}
+
+	cfg, err := pgx.ParseConfig(env.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	db := stdlib.OpenDB(*cfg)
+	if env.PoolConfig != nil {
+		env.PoolConfig.ApplyDB(db)
+	}
+	return &pgxStdlib{pq{db: db}}, nil
+}
+
+func (p *pgxStdlib) DBStats() sql.DBStats {
+	return p.db.Stats()
+}
+
+func (p *pgxStdlib) Close() error {
+	return p.db.Close()
+}
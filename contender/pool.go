@@ -0,0 +1,73 @@
+package contender
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolConfig sizes a connection pool. It is applied to Env.DB, to
+// Env.Pool and to the pools contenders open themselves, so that every
+// library runs with the same limits.
+type PoolConfig struct {
+	// MaxOpen caps the open connections; 0 means unlimited for
+	// database/sql and pgxpool's default for pgxpool, which has no
+	// unlimited setting.
+	MaxOpen int
+	// MaxIdle caps the idle connections kept for reuse; 0 keeps none.
+	// pgxpool has no such cap and keeps up to MaxOpen idle connections.
+	MaxIdle int
+	// MaxLifetime closes connections that have been open this long; 0
+	// means forever for database/sql and pgxpool's default for pgxpool.
+	MaxLifetime time.Duration
+}
+
+// ParsePoolConfig parses "OPEN:IDLE:LIFETIME", where LIFETIME is a
+// time.Duration or 0.
+func ParsePoolConfig(s string) (PoolConfig, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) != 3 {
+		return PoolConfig{}, fmt.Errorf("contender: invalid pool configuration %q, want OPEN:IDLE:LIFETIME", s)
+	}
+
+	open, err1 := strconv.Atoi(fields[0])
+	idle, err2 := strconv.Atoi(fields[1])
+	lifetime, err3 := time.ParseDuration(fields[2])
+	if err1 != nil || err2 != nil || err3 != nil || open < 0 || idle < 0 || lifetime < 0 {
+		return PoolConfig{}, fmt.Errorf("contender: invalid pool configuration %q", s)
+	}
+	return PoolConfig{MaxOpen: open, MaxIdle: idle, MaxLifetime: lifetime}, nil
+}
+
+// String returns the configuration in the form ParsePoolConfig accepts.
+func (c PoolConfig) String() string {
+	return fmt.Sprintf("%d:%d:%s", c.MaxOpen, c.MaxIdle, c.MaxLifetime)
+}
+
+// ApplyDB sets the limits of a database/sql pool.
+func (c PoolConfig) ApplyDB(db *sql.DB) {
+	db.SetMaxOpenConns(c.MaxOpen)
+	db.SetMaxIdleConns(c.MaxIdle)
+	db.SetConnMaxLifetime(c.MaxLifetime)
+}
+
+// ApplyPgx sets the limits of a pgxpool configuration before the pool is
+// created.
+func (c PoolConfig) ApplyPgx(cfg *pgxpool.Config) {
+	if c.MaxOpen > 0 {
+		cfg.MaxConns = int32(c.MaxOpen)
+	}
+	if c.MaxLifetime > 0 {
+		cfg.MaxConnLifetime = c.MaxLifetime
+	}
+}
+
+// PoolOwner is implemented by contenders that open their own database/sql
+// pool instead of using Env.DB, so that its statistics can be read.
+type PoolOwner interface {
+	DBStats() sql.DBStats
+}
@@ -0,0 +1,118 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+	"time"
+)
+
+func init() {
+	Register("Pq", newPq)
+}
+
+// pq scans the join by hand with database/sql and lib/pq.
+type pq struct {
+	db *sql.DB
+}
+
+func newPq(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &pq{db: env.DB}, nil
+}
+
+func (p *pq) FetchAll(ctx context.Context) ([]Order, error) {
+	rows, err := p.db.QueryContext(ctx, joinQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	f := newFolder()
+	for rows.Next() {
+		var row joinRow
+		if err := rows.Scan(&row.ID, &row.CustomerName, &row.CreatedAt, &row.OrderItemID, &row.OrderID, &row.ProductName, &row.Price, &row.Quantity); err != nil {
+			return nil, err
+		}
+		f.add(&row)
+	}
+	return f.orders, rows.Err()
+}
+
+func (p *pq) FetchOne(ctx context.Context, id int32) (Order, error) {
+	rows, err := p.db.QueryContext(ctx, joinQueryOne, id)
+	if err != nil {
+		return Order{}, err
+	}
+	defer rows.Close()
+
+	var order Order
+	for first := true; rows.Next(); first = false {
+		var row joinRow
+		if err := rows.Scan(&row.ID, &row.CustomerName, &row.CreatedAt, &row.OrderItemID, &row.OrderID, &row.ProductName, &row.Price, &row.Quantity); err != nil {
+			return Order{}, err
+		}
+		if first {
+			order = row.order()
+		}
+		order.Itens = append(order.Itens, row.item())
+	}
+	return order, rows.Err()
+}
+
+func (p *pq) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		rows, err := p.db.QueryContext(ctx, joinQuery)
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		defer rows.Close()
+
+		streamJoin(rows, func(row *joinRow) error {
+			return rows.Scan(&row.ID, &row.CustomerName, &row.CreatedAt, &row.OrderItemID, &row.OrderID, &row.ProductName, &row.Price, &row.Quantity)
+		}, yield)
+	}
+}
+
+func (p *pq) ExplainAll(ctx context.Context) (time.Duration, error) {
+	return explainAnalyze(ctx, p.db, joinQuery)
+}
+
+func (p *pq) FetchAllCursor(ctx context.Context, size int) ([]Order, error) {
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, declareJoinCursor); err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	fetch := fetchJoinCursor(size)
+	err = drainCursor(size, func() (int, error) {
+		rows, err := tx.QueryContext(ctx, fetch)
+		if err != nil {
+			return 0, err
+		}
+		defer rows.Close()
+
+		n := 0
+		for ; rows.Next(); n++ {
+			var row joinRow
+			if err := rows.Scan(&row.ID, &row.CustomerName, &row.CreatedAt, &row.OrderItemID, &row.OrderID, &row.ProductName, &row.Price, &row.Quantity); err != nil {
+				return 0, err
+			}
+			f.add(&row)
+		}
+		return n, rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f.orders, tx.Commit()
+}
@@ -0,0 +1,351 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"iter"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+)
+
+func init() {
+	for _, v := range []struct {
+		name            string
+		query, queryOne string
+		decode          func([]byte) ([]model.OrderItems, error)
+	}{
+		{"PqJsonAgg", jsonAggColumns + jsonGroupAll, jsonAggColumns + jsonGroupOne, decodeItems},
+		{"PqJsonbAgg", jsonbAggColumns + jsonGroupAll, jsonbAggColumns + jsonGroupOne, decodeItems},
+		{"PqJsonArray", jsonArrayColumns + jsonGroupAll, jsonArrayColumns + jsonGroupOne, decodeItemArrays},
+		{"PqRowToJson", rowToJsonQuery, rowToJsonQueryOne, decodeItems},
+	} {
+		Register(v.name, func(_ context.Context, env Env) (Contender, error) {
+			if env.DB == nil {
+				return nil, ErrUnsupported
+			}
+			return &pqJsonAgg{db: env.DB, query: v.query, queryOne: v.queryOne, decode: v.decode}, nil
+		})
+	}
+	Register("PqJsonDocument", newPqJsonDocument)
+}
+
+// jsonItemObject builds an item as a JSON object keyed like jsonItem.
+const jsonItemObject = `json_build_object(
+			   'id', order_items.id,
+			   'order_id', order_items.order_id,
+			   'product_name', order_items.product_name,
+			   'price', order_items.price,
+			   'quantity', order_items.quantity
+		   )`
+
+const jsonOrderColumns = `
+	SELECT orders.id AS "orders.id",
+		   orders.customer_name AS "orders.customer_name",
+		   orders.created_at AS "orders.created_at",
+		   `
+
+const jsonAggFrom = ` AS "order_items"
+	FROM public.orders
+	INNER JOIN public.order_items ON (orders.id = order_items.order_id)
+`
+
+// jsonAggColumns aggregates each order's items into a JSON array of
+// objects.
+const jsonAggColumns = jsonOrderColumns + `json_agg(` + jsonItemObject + `)` + jsonAggFrom
+
+// jsonbAggColumns builds the same array as jsonb, which Postgres sends as
+// text with its keys sorted.
+const jsonbAggColumns = jsonOrderColumns + `jsonb_agg(jsonb_build_object(
+			   'id', order_items.id,
+			   'order_id', order_items.order_id,
+			   'product_name', order_items.product_name,
+			   'price', order_items.price,
+			   'quantity', order_items.quantity
+		   ))` + jsonAggFrom
+
+// jsonArrayColumns encodes each item as an array in jsonItemArray's field
+// order instead of an object, leaving the keys out of the payload.
+const jsonArrayColumns = jsonOrderColumns + `json_agg(json_build_array(
+			   order_items.id,
+			   order_items.order_id,
+			   order_items.product_name,
+			   order_items.price,
+			   order_items.quantity
+		   ))` + jsonAggFrom
+
+const jsonGroupAll = `
+	GROUP BY orders.id, orders.customer_name, orders.created_at
+	ORDER BY orders.id ASC;
+`
+
+const jsonGroupOne = `
+	WHERE orders.id = $1
+	GROUP BY orders.id, orders.customer_name, orders.created_at
+	ORDER BY orders.id ASC;
+`
+
+// rowToJsonColumns turns every row of a correlated subquery over the
+// order's items into JSON with row_to_json, so the keys are the subquery's
+// column names. EXISTS keeps the orders without items out, as the join
+// does.
+const rowToJsonColumns = jsonOrderColumns + `(
+			   SELECT json_agg(row_to_json(items))
+			   FROM (
+				   SELECT id, order_id, product_name, price, quantity
+				   FROM public.order_items
+				   WHERE order_items.order_id = orders.id
+			   ) items
+		   ) AS "order_items"
+	FROM public.orders
+	WHERE EXISTS (SELECT 1 FROM public.order_items WHERE order_items.order_id = orders.id)
+`
+
+const rowToJsonQuery = rowToJsonColumns + `
+	ORDER BY orders.id ASC;
+`
+
+const rowToJsonQueryOne = rowToJsonColumns + `
+	AND orders.id = $1
+	ORDER BY orders.id ASC;
+`
+
+// jsonDocumentFrom groups the items of each order like jsonAggColumns,
+// for jsonDocumentSelect to aggregate once more.
+const jsonDocumentFrom = `
+	FROM (
+		SELECT orders.id, orders.customer_name, orders.created_at,
+			   json_agg(` + jsonItemObject + `) AS items
+		FROM public.orders
+		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
+`
+
+// jsonDocumentSelect builds the whole result as one JSON array of orders.
+// created_at is read as UTC, as lib/pq reads a timestamp, and sent with
+// its offset so it decodes into a time.Time.
+const jsonDocumentSelect = `
+	SELECT coalesce(json_agg(json_build_object(
+			   'id', orders.id,
+			   'customer_name', orders.customer_name,
+			   'created_at', orders.created_at AT TIME ZONE 'UTC',
+			   'items', orders.items
+		   ) ORDER BY orders.id), '[]')
+`
+
+const jsonDocumentGroup = `
+		GROUP BY orders.id, orders.customer_name, orders.created_at
+	) orders;
+`
+
+const jsonDocumentQuery = jsonDocumentSelect + jsonDocumentFrom + jsonDocumentGroup
+
+const jsonDocumentQueryOne = jsonDocumentSelect + jsonDocumentFrom + `
+		WHERE orders.id = $1` + jsonDocumentGroup
+
+type jsonItem struct {
+	OrderItemID int32   `json:"id"`
+	OrderID     *int32  `json:"order_id"`
+	ProductName string  `json:"product_name"`
+	Price       float64 `json:"price"`
+	Quantity    *int32  `json:"quantity"`
+}
+
+func (item *jsonItem) model() model.OrderItems {
+	return model.OrderItems{
+		ID:          item.OrderItemID,
+		OrderID:     item.OrderID,
+		ProductName: item.ProductName,
+		Price:       item.Price,
+		Quantity:    item.Quantity,
+	}
+}
+
+// jsonItemArray is a jsonItem encoded positionally by json_build_array.
+type jsonItemArray jsonItem
+
+// UnmarshalJSON decodes each element of the array straight into its
+// field: encoding/json decodes into the pointer an interface holds.
+func (item *jsonItemArray) UnmarshalJSON(data []byte) error {
+	fields := [...]any{&item.OrderItemID, &item.OrderID, &item.ProductName, &item.Price, &item.Quantity}
+	return json.Unmarshal(data, &fields)
+}
+
+// decodeItems unmarshals an aggregated items array into the canonical
+// model.
+func decodeItems(data []byte) ([]model.OrderItems, error) {
+	var itens []jsonItem
+	if err := json.Unmarshal(data, &itens); err != nil {
+		return nil, err
+	}
+
+	items := make([]model.OrderItems, len(itens))
+	for i := range itens {
+		items[i] = itens[i].model()
+	}
+	return items, nil
+}
+
+// decodeItemArrays is decodeItems for items encoded by json_build_array.
+func decodeItemArrays(data []byte) ([]model.OrderItems, error) {
+	var itens []jsonItemArray
+	if err := json.Unmarshal(data, &itens); err != nil {
+		return nil, err
+	}
+
+	items := make([]model.OrderItems, len(itens))
+	for i := range itens {
+		items[i] = (*jsonItem)(&itens[i]).model()
+	}
+	return items, nil
+}
+
+// pqJsonAgg lets Postgres group the items of each order into JSON, so the
+// result has one row per order. The variants differ in how the JSON is
+// built and decoded.
+type pqJsonAgg struct {
+	db              *sql.DB
+	query, queryOne string
+	decode          func([]byte) ([]model.OrderItems, error)
+}
+
+func (p *pqJsonAgg) FetchAll(ctx context.Context) ([]Order, error) {
+	rows, err := p.db.QueryContext(ctx, p.query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []Order
+	for rows.Next() {
+		order, err := p.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func (p *pqJsonAgg) FetchOne(ctx context.Context, id int32) (Order, error) {
+	rows, err := p.db.QueryContext(ctx, p.queryOne, id)
+	if err != nil {
+		return Order{}, err
+	}
+	defer rows.Close()
+
+	var order Order
+	if rows.Next() {
+		if order, err = p.scan(rows); err != nil {
+			return Order{}, err
+		}
+	}
+	return order, rows.Err()
+}
+
+// Stream needs no grouping, since every row already holds a whole order.
+func (p *pqJsonAgg) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		rows, err := p.db.QueryContext(ctx, p.query)
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			order, err := p.scan(rows)
+			if err != nil {
+				yield(Order{}, err)
+				return
+			}
+			if !yield(order, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(Order{}, err)
+		}
+	}
+}
+
+func (p *pqJsonAgg) ExplainAll(ctx context.Context) (time.Duration, error) {
+	return explainAnalyze(ctx, p.db, p.query)
+}
+
+func (p *pqJsonAgg) scan(rows *sql.Rows) (Order, error) {
+	var (
+		order          Order
+		orderItemsJSON []byte
+		err            error
+	)
+	if err := rows.Scan(&order.ID, &order.CustomerName, &order.CreatedAt, &orderItemsJSON); err != nil {
+		return Order{}, err
+	}
+	if order.Itens, err = p.decode(orderItemsJSON); err != nil {
+		return Order{}, err
+	}
+	return order, nil
+}
+
+// jsonOrder is an order of the document jsonDocumentQuery builds.
+type jsonOrder struct {
+	ID           int32      `json:"id"`
+	CustomerName string     `json:"customer_name"`
+	CreatedAt    *time.Time `json:"created_at"`
+	Items        []jsonItem `json:"items"`
+}
+
+// pqJsonDocument has Postgres build every order and its items into a
+// single JSON document, read as one value and decoded in one call.
+type pqJsonDocument struct {
+	db *sql.DB
+}
+
+func newPqJsonDocument(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &pqJsonDocument{db: env.DB}, nil
+}
+
+func (p *pqJsonDocument) FetchAll(ctx context.Context) ([]Order, error) {
+	return p.fetch(ctx, jsonDocumentQuery)
+}
+
+func (p *pqJsonDocument) FetchOne(ctx context.Context, id int32) (Order, error) {
+	orders, err := p.fetch(ctx, jsonDocumentQueryOne, id)
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+func (p *pqJsonDocument) ExplainAll(ctx context.Context) (time.Duration, error) {
+	return explainAnalyze(ctx, p.db, jsonDocumentQuery)
+}
+
+func (p *pqJsonDocument) fetch(ctx context.Context, query string, args ...any) ([]Order, error) {
+	var document []byte
+	if err := p.db.QueryRowContext(ctx, query, args...).Scan(&document); err != nil {
+		return nil, err
+	}
+
+	var decoded []jsonOrder
+	if err := json.Unmarshal(document, &decoded); err != nil {
+		return nil, err
+	}
+
+	orders := make([]Order, len(decoded))
+	for i := range decoded {
+		o := &decoded[i]
+		orders[i] = Order{
+			Orders: model.Orders{ID: o.ID, CustomerName: o.CustomerName, CreatedAt: o.CreatedAt},
+			Itens:  make([]model.OrderItems, len(o.Items)),
+		}
+		for j := range o.Items {
+			orders[i].Itens[j] = o.Items[j].model()
+		}
+	}
+	return orders, nil
+}
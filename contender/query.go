@@ -0,0 +1,219 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+)
+
+const joinColumns = `
+	SELECT orders.id AS "orders.id",
+		orders.customer_name AS "orders.customer_name",
+		orders.created_at AS "orders.created_at",
+		order_items.id AS "order_items.id",
+		order_items.order_id AS "order_items.order_id",
+		order_items.product_name AS "order_items.product_name",
+		order_items.price AS "order_items.price",
+		order_items.quantity AS "order_items.quantity"
+	FROM public.orders
+		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
+`
+
+// joinQuery returns one row per order item, ordered by order id.
+const joinQuery = joinColumns + `
+	ORDER BY orders.id ASC;
+`
+
+// joinQueryOne is joinQuery restricted to the order id bound to $1.
+const joinQueryOne = joinColumns + `
+	WHERE orders.id = $1
+	ORDER BY orders.id ASC;
+`
+
+// joinCursor is the name of the server-side cursor the Cursor contenders
+// declare over the join.
+const joinCursor = "join_cursor"
+
+// declareJoinCursor declares joinCursor over joinQuery.
+const declareJoinCursor = "DECLARE " + joinCursor + " NO SCROLL CURSOR FOR " + joinQuery
+
+// fetchJoinCursor returns the statement fetching the next size rows of
+// joinCursor. FETCH takes no parameters, so size is inlined.
+func fetchJoinCursor(size int) string {
+	return "FETCH " + strconv.Itoa(size) + " FROM " + joinCursor
+}
+
+// drainCursor calls fetch, which reads one FETCH of at most size rows and
+// returns how many it read, until a FETCH comes back short.
+func drainCursor(size int, fetch func() (int, error)) error {
+	for {
+		n, err := fetch()
+		if err != nil || n < size {
+			return err
+		}
+	}
+}
+
+// ordersQuery returns every order without its items, for the contenders
+// that load the items in a second query.
+const ordersQuery = `
+	SELECT id, customer_name, created_at
+	FROM public.orders
+	ORDER BY id ASC;
+`
+
+// ordersQueryOne is ordersQuery restricted to the order id bound to $1.
+const ordersQueryOne = `
+	SELECT id, customer_name, created_at
+	FROM public.orders
+	WHERE id = $1;
+`
+
+// maxBindParams is the most parameters Postgres accepts in one statement.
+// Loaders that bind each order id in an IN list cannot load more orders.
+const maxBindParams = 65535
+
+// itemsByOrdersQuery returns the items of the orders whose ids are in the
+// array bound to $1.
+const itemsByOrdersQuery = `
+	SELECT id, order_id, product_name, price, quantity
+	FROM public.order_items
+	WHERE order_id = ANY($1);
+`
+
+// itemsByOrderQuery returns the items of the order id bound to $1.
+const itemsByOrderQuery = `
+	SELECT id, order_id, product_name, price, quantity
+	FROM public.order_items
+	WHERE order_id = $1;
+`
+
+// explainAnalyze runs query under EXPLAIN ANALYZE and returns the
+// execution time Postgres reports. query must take no parameters.
+func explainAnalyze(ctx context.Context, db *sql.DB, query string) (time.Duration, error) {
+	var plan []byte
+	if err := db.QueryRowContext(ctx, "EXPLAIN (ANALYZE, FORMAT JSON) "+query).Scan(&plan); err != nil {
+		return 0, err
+	}
+
+	var plans []struct {
+		ExecutionTime float64 `json:"Execution Time"`
+	}
+	if err := json.Unmarshal(plan, &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, errors.New("contender: empty plan")
+	}
+	return time.Duration(plans[0].ExecutionTime * float64(time.Millisecond)), nil
+}
+
+// joinRow is a single row of joinQuery.
+type joinRow struct {
+	ID           int32      `db:"orders.id"`
+	CustomerName string     `db:"orders.customer_name"`
+	CreatedAt    *time.Time `db:"orders.created_at"`
+	OrderItemID  int32      `db:"order_items.id"`
+	OrderID      *int32     `db:"order_items.order_id"`
+	ProductName  string     `db:"order_items.product_name"`
+	Price        float64    `db:"order_items.price"`
+	Quantity     *int32     `db:"order_items.quantity"`
+}
+
+func (r *joinRow) order() Order {
+	return Order{
+		Orders: model.Orders{
+			ID:           r.ID,
+			CustomerName: r.CustomerName,
+			CreatedAt:    r.CreatedAt,
+		},
+	}
+}
+
+func (r *joinRow) item() model.OrderItems {
+	return model.OrderItems{
+		ID:          r.OrderItemID,
+		OrderID:     r.OrderID,
+		ProductName: r.ProductName,
+		Price:       r.Price,
+		Quantity:    r.Quantity,
+	}
+}
+
+// folder groups join rows into orders, keeping the order in which each
+// order id was first seen.
+type folder struct {
+	orders   []Order
+	orderIdx map[int32]int
+}
+
+func newFolder() *folder {
+	return &folder{orderIdx: make(map[int32]int)}
+}
+
+func (f *folder) add(row *joinRow) {
+	idx, ok := f.orderIdx[row.ID]
+	if !ok {
+		f.orders = append(f.orders, row.order())
+		idx = len(f.orders) - 1
+		f.orderIdx[row.ID] = idx
+	}
+	f.orders[idx].Itens = append(f.orders[idx].Itens, row.item())
+}
+
+// addPair is add for rows that come split into their order and item.
+func (f *folder) addPair(order model.Orders, item model.OrderItems) {
+	idx, ok := f.orderIdx[order.ID]
+	if !ok {
+		f.orders = append(f.orders, Order{Orders: order})
+		idx = len(f.orders) - 1
+		f.orderIdx[order.ID] = idx
+	}
+	f.orders[idx].Itens = append(f.orders[idx].Itens, item)
+}
+
+// addOrder adds an order without items, which addItem fills in later.
+func (f *folder) addOrder(order model.Orders) {
+	f.orders = append(f.orders, Order{Orders: order})
+	f.orderIdx[order.ID] = len(f.orders) - 1
+}
+
+// addItem appends item to the order addOrder added with its order id.
+// Items of any other order are dropped.
+func (f *folder) addItem(item model.OrderItems) {
+	if item.OrderID == nil {
+		return
+	}
+	if idx, ok := f.orderIdx[*item.OrderID]; ok {
+		f.orders[idx].Itens = append(f.orders[idx].Itens, item)
+	}
+}
+
+// appendChunk appends orders mapped from one FETCH to orders, merging the
+// first of them into the last of orders when the FETCH boundary split its
+// items.
+func appendChunk(orders, chunk []Order) []Order {
+	if len(orders) > 0 && len(chunk) > 0 && orders[len(orders)-1].ID == chunk[0].ID {
+		last := &orders[len(orders)-1]
+		last.Itens = append(last.Itens, chunk[0].Itens...)
+		chunk = chunk[1:]
+	}
+	return append(orders, chunk...)
+}
+
+// foldOne folds the rows of joinQueryOne into a single order.
+func foldOne(rows []joinRow) Order {
+	var order Order
+	for i := range rows {
+		if i == 0 {
+			order = rows[i].order()
+		}
+		order.Itens = append(order.Itens, rows[i].item())
+	}
+	return order
+}
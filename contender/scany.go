@@ -0,0 +1,218 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+)
+
+func init() {
+	builders := []struct {
+		name  string
+		build joinBuilder
+	}{
+		{"Squirrel", squirrelJoin},
+		{"Goqu", goquJoin},
+	}
+	for _, b := range builders {
+		Register(b.name+"Pgxscan", newScany(b.build, false, false))
+		Register(b.name+"PgxscanFlat", newScany(b.build, false, true))
+		Register(b.name+"Sqlscan", newScany(b.build, true, false))
+		Register(b.name+"SqlscanFlat", newScany(b.build, true, true))
+	}
+}
+
+// joinBuilder renders the join of joinQuery with a query builder,
+// restricted to one order when id is not nil.
+type joinBuilder func(id *int32) (string, []any, error)
+
+// JoinAliases are the column aliases of joinColumns. scany maps them to
+// the db tags of joinRow and to the fields nested in scanyRow.
+var JoinAliases = []string{
+	"orders.id",
+	"orders.customer_name",
+	"orders.created_at",
+	"order_items.id",
+	"order_items.order_id",
+	"order_items.product_name",
+	"order_items.price",
+	"order_items.quantity",
+}
+
+// SquirrelJoin starts the join of joinQuery with squirrel, for callers to
+// restrict before rendering it.
+func SquirrelJoin() sq.SelectBuilder {
+	columns := make([]string, len(JoinAliases))
+	for i, c := range JoinAliases {
+		columns[i] = c + ` AS "` + c + `"`
+	}
+
+	return sq.Select(columns...).
+		From("orders").
+		Join("order_items ON orders.id = order_items.order_id").
+		OrderBy("orders.id ASC").
+		PlaceholderFormat(sq.Dollar)
+}
+
+func squirrelJoin(id *int32) (string, []any, error) {
+	q := SquirrelJoin()
+	if id != nil {
+		q = q.Where(sq.Eq{"orders.id": *id})
+	}
+	return q.ToSql()
+}
+
+// GoquJoin is SquirrelJoin for goqu.
+func GoquJoin() *goqu.SelectDataset {
+	columns := make([]any, len(JoinAliases))
+	for i, c := range JoinAliases {
+		columns[i] = goqu.I(c).As(goqu.C(c))
+	}
+
+	return goqu.Dialect("postgres").
+		From("orders").
+		Select(columns...).
+		InnerJoin(goqu.T("order_items"), goqu.On(goqu.I("orders.id").Eq(goqu.I("order_items.order_id")))).
+		Order(goqu.I("orders.id").Asc()).
+		Prepared(true)
+}
+
+func goquJoin(id *int32) (string, []any, error) {
+	q := GoquJoin()
+	if id != nil {
+		q = q.Where(goqu.I("orders.id").Eq(*id))
+	}
+	return q.ToSQL()
+}
+
+// scanyRow is a join row split into the canonical models, which scany
+// fills from the "orders." and "order_items." prefixed columns.
+type scanyRow struct {
+	Orders     model.Orders     `db:"orders"`
+	OrderItems model.OrderItems `db:"order_items"`
+}
+
+// scany pairs a query builder with scany. By default it scans the join
+// into scanyRow and groups the rows; flat scans into joinRow and folds
+// it like the hand-written contenders.
+type scany struct {
+	build joinBuilder
+	flat  bool
+	// Exactly one of pool and db is set.
+	pool pgxscan.Querier
+	db   *sql.DB
+}
+
+func newScany(build joinBuilder, sqlscan, flat bool) Factory {
+	return func(_ context.Context, env Env) (Contender, error) {
+		s := &scany{build: build, flat: flat}
+		switch {
+		case sqlscan && env.DB != nil:
+			s.db = env.DB
+		case !sqlscan && env.Pool != nil:
+			s.pool = env.Pool
+		default:
+			return nil, ErrUnsupported
+		}
+		return s, nil
+	}
+}
+
+func (s *scany) fetch(ctx context.Context, id *int32) ([]Order, error) {
+	query, args, err := s.build(id)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	if s.flat {
+		var rows []joinRow
+		if err := s.selectRows(ctx, &rows, query, args); err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			f.add(&rows[i])
+		}
+		return f.orders, nil
+	}
+
+	var rows []scanyRow
+	if err := s.selectRows(ctx, &rows, query, args); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		f.addPair(rows[i].Orders, rows[i].OrderItems)
+	}
+	return f.orders, nil
+}
+
+func (s *scany) selectRows(ctx context.Context, dst any, query string, args []any) error {
+	if s.db != nil {
+		return sqlscan.Select(ctx, s.db, dst, query, args...)
+	}
+	return pgxscan.Select(ctx, s.pool, dst, query, args...)
+}
+
+func (s *scany) FetchAll(ctx context.Context) ([]Order, error) {
+	return s.fetch(ctx, nil)
+}
+
+func (s *scany) FetchOne(ctx context.Context, id int32) (Order, error) {
+	orders, err := s.fetch(ctx, &id)
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+// Stream scans the join one row at a time with scany's RowScanner.
+func (s *scany) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		query, args, err := s.build(nil)
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+
+		var (
+			rows    cursor
+			scanner interface{ Scan(dst any) error }
+		)
+		if s.db != nil {
+			r, err := s.db.QueryContext(ctx, query, args...)
+			if err != nil {
+				yield(Order{}, err)
+				return
+			}
+			defer r.Close()
+			rows, scanner = r, sqlscan.NewRowScanner(r)
+		} else {
+			r, err := s.pool.Query(ctx, query, args...)
+			if err != nil {
+				yield(Order{}, err)
+				return
+			}
+			defer r.Close()
+			rows, scanner = r, pgxscan.NewRowScanner(r)
+		}
+
+		if s.flat {
+			streamJoin(rows, func(row *joinRow) error {
+				return scanner.Scan(row)
+			}, yield)
+			return
+		}
+		streamPairs(rows, func() (model.Orders, model.OrderItems, error) {
+			var row scanyRow
+			err := scanner.Scan(&row)
+			return row.Orders, row.OrderItems, err
+		}, yield)
+	}
+}
@@ -0,0 +1,94 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/sqlboiler/models"
+)
+
+func init() {
+	Register("SqlBoiler", newSqlBoiler)
+}
+
+// sqlBoiler eager-loads the order items with qm.Load, which issues one
+// query for the orders and one for their items and scans both with
+// generated, type-specific code.
+type sqlBoiler struct {
+	db *sql.DB
+}
+
+// MaxOrders bounds SQLBoiler to what its items query can bind: qm.Load
+// puts every order id in an IN list, one parameter each.
+func (*sqlBoiler) MaxOrders() int {
+	return maxBindParams
+}
+
+func newSqlBoiler(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &sqlBoiler{db: env.DB}, nil
+}
+
+func (s *sqlBoiler) FetchAll(ctx context.Context) ([]Order, error) {
+	dest, err := models.Orders(qm.Load(models.OrderRels.OrderItems)).All(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]Order, len(dest))
+	for i, o := range dest {
+		orders[i] = sqlBoilerCanonical(o)
+	}
+	return orders, nil
+}
+
+func (s *sqlBoiler) FetchOne(ctx context.Context, id int32) (Order, error) {
+	dest, err := models.Orders(
+		models.OrderWhere.ID.EQ(int(id)),
+		qm.Load(models.OrderRels.OrderItems),
+	).One(ctx, s.db)
+	if err != nil {
+		return Order{}, err
+	}
+	return sqlBoilerCanonical(dest), nil
+}
+
+// sqlBoilerCanonical copies a SQLBoiler order and its loaded items into
+// the canonical model.
+func sqlBoilerCanonical(o *models.Order) Order {
+	canonical := Order{
+		Orders: model.Orders{
+			ID:           int32(o.ID),
+			CustomerName: o.CustomerName,
+			CreatedAt:    o.CreatedAt.Ptr(),
+		},
+	}
+	if o.R == nil {
+		return canonical
+	}
+
+	canonical.Itens = make([]model.OrderItems, len(o.R.OrderItems))
+	for i, item := range o.R.OrderItems {
+		canonical.Itens[i] = model.OrderItems{
+			ID:          int32(item.ID),
+			OrderID:     nullInt32(item.OrderID),
+			ProductName: item.ProductName,
+			Price:       item.Price,
+			Quantity:    nullInt32(item.Quantity),
+		}
+	}
+	return canonical
+}
+
+func nullInt32(n null.Int) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	v := int32(n.Int)
+	return &v
+}
@@ -0,0 +1,167 @@
+package contender
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/sqlc/sqlcpgx"
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/sqlc/sqlcpq"
+)
+
+func init() {
+	Register("SqlcPgx", newSqlcPgx(false))
+	Register("SqlcPgxEmbed", newSqlcPgx(true))
+	Register("SqlcPq", newSqlcPq(false))
+	Register("SqlcPqEmbed", newSqlcPq(true))
+}
+
+// sqlcPgx runs the queries sqlc generated for pgx/v5 on Env.Pool. The
+// flat rows of the plain queries convert to joinRow, and the embed
+// variants scan straight into the order and item models.
+type sqlcPgx struct {
+	q     *sqlcpgx.Queries
+	embed bool
+}
+
+func newSqlcPgx(embed bool) Factory {
+	return func(_ context.Context, env Env) (Contender, error) {
+		if env.Pool == nil {
+			return nil, ErrUnsupported
+		}
+		return &sqlcPgx{q: sqlcpgx.New(env.Pool), embed: embed}, nil
+	}
+}
+
+func (s *sqlcPgx) FetchAll(ctx context.Context) ([]Order, error) {
+	if s.embed {
+		return sqlcList(ctx, s.q.ListOrdersWithItemsEmbed, addSqlcEmbed)
+	}
+	return sqlcList(ctx, s.q.ListOrdersWithItems, addSqlcRow)
+}
+
+func (s *sqlcPgx) FetchOne(ctx context.Context, id int32) (Order, error) {
+	if s.embed {
+		return sqlcGet(ctx, s.q.GetOrderWithItemsEmbed, id, addSqlcEmbed)
+	}
+	return sqlcGet(ctx, s.q.GetOrderWithItems, id, addSqlcRow)
+}
+
+// sqlcPq is sqlcPgx for the database/sql emitter, run on Env.DB.
+type sqlcPq struct {
+	q     *sqlcpq.Queries
+	embed bool
+}
+
+func newSqlcPq(embed bool) Factory {
+	return func(_ context.Context, env Env) (Contender, error) {
+		if env.DB == nil {
+			return nil, ErrUnsupported
+		}
+		return &sqlcPq{q: sqlcpq.New(env.DB), embed: embed}, nil
+	}
+}
+
+func (s *sqlcPq) FetchAll(ctx context.Context) ([]Order, error) {
+	if s.embed {
+		return sqlcList(ctx, s.q.ListOrdersWithItemsEmbed, addSqlcEmbed)
+	}
+	return sqlcList(ctx, s.q.ListOrdersWithItems, addSqlcRow)
+}
+
+func (s *sqlcPq) FetchOne(ctx context.Context, id int32) (Order, error) {
+	if s.embed {
+		return sqlcGet(ctx, s.q.GetOrderWithItemsEmbed, id, addSqlcEmbed)
+	}
+	return sqlcGet(ctx, s.q.GetOrderWithItems, id, addSqlcRow)
+}
+
+// sqlcList runs list, a generated query, and folds the rows it returns,
+// adding each with add.
+func sqlcList[R any](ctx context.Context, list func(context.Context) ([]R, error), add func(*folder, *R)) ([]Order, error) {
+	rows, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	for i := range rows {
+		add(f, &rows[i])
+	}
+	return f.orders, nil
+}
+
+// sqlcGet is sqlcList for a generated query of the order with id.
+func sqlcGet[R any](ctx context.Context, get func(context.Context, int32) ([]R, error), id int32, add func(*folder, *R)) (Order, error) {
+	orders, err := sqlcList(ctx, func(ctx context.Context) ([]R, error) {
+		return get(ctx, id)
+	}, add)
+	if err != nil {
+		return Order{}, err
+	}
+	return firstOrder(orders), nil
+}
+
+// sqlcRow matches the flat rows both emitters generate, which have
+// joinRow's fields without its tags.
+type sqlcRow interface {
+	~struct {
+		ID           int32
+		CustomerName string
+		CreatedAt    *time.Time
+		OrderItemID  int32
+		OrderID      *int32
+		ProductName  string
+		Price        float64
+		Quantity     *int32
+	}
+}
+
+func addSqlcRow[R sqlcRow](f *folder, row *R) {
+	r := joinRow(*row)
+	f.add(&r)
+}
+
+// sqlcEmbedRow matches the rows of the sqlc.embed queries, an order and an
+// item model of either emitter.
+type sqlcEmbedRow[O sqlcOrder, I sqlcItem] interface {
+	~struct {
+		Order     O
+		OrderItem I
+	}
+}
+
+type sqlcOrder interface {
+	~struct {
+		ID           int32
+		CustomerName string
+		CreatedAt    *time.Time
+	}
+}
+
+type sqlcItem interface {
+	~struct {
+		ID          int32
+		OrderID     *int32
+		ProductName string
+		Price       float64
+		Quantity    *int32
+	}
+}
+
+func addSqlcEmbed[R sqlcEmbedRow[O, I], O sqlcOrder, I sqlcItem](f *folder, row *R) {
+	r := struct {
+		Order     O
+		OrderItem I
+	}(*row)
+	f.addPair(model.Orders(r.Order), model.OrderItems(r.OrderItem))
+}
+
+// firstOrder returns the only order a single-order query folds into, or
+// the zero Order if it matched nothing.
+func firstOrder(orders []Order) Order {
+	if len(orders) == 0 {
+		return Order{}
+	}
+	return orders[0]
+}
@@ -0,0 +1,89 @@
+package contender
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	Register("Sqlx", newSqlx)
+}
+
+type sqlxContender struct {
+	db *sqlx.DB
+}
+
+func newSqlx(_ context.Context, env Env) (Contender, error) {
+	if env.DB == nil {
+		return nil, ErrUnsupported
+	}
+	return &sqlxContender{db: sqlx.NewDb(env.DB, "postgres")}, nil
+}
+
+func (s *sqlxContender) FetchAll(ctx context.Context) ([]Order, error) {
+	var rows []joinRow
+	if err := s.db.SelectContext(ctx, &rows, joinQuery); err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	for i := range rows {
+		f.add(&rows[i])
+	}
+	return f.orders, nil
+}
+
+func (s *sqlxContender) FetchOne(ctx context.Context, id int32) (Order, error) {
+	var rows []joinRow
+	if err := s.db.SelectContext(ctx, &rows, joinQueryOne, id); err != nil {
+		return Order{}, err
+	}
+	return foldOne(rows), nil
+}
+
+func (s *sqlxContender) Stream(ctx context.Context) iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		rows, err := s.db.QueryxContext(ctx, joinQuery)
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		defer rows.Close()
+
+		streamJoin(rows, func(row *joinRow) error {
+			return rows.StructScan(row)
+		}, yield)
+	}
+}
+
+func (s *sqlxContender) FetchAllCursor(ctx context.Context, size int) ([]Order, error) {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, declareJoinCursor); err != nil {
+		return nil, err
+	}
+
+	f := newFolder()
+	fetch := fetchJoinCursor(size)
+	err = drainCursor(size, func() (int, error) {
+		var rows []joinRow
+		if err := tx.SelectContext(ctx, &rows, fetch); err != nil {
+			return 0, err
+		}
+		for i := range rows {
+			f.add(&rows[i])
+		}
+		return len(rows), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f.orders, tx.Commit()
+}
@@ -0,0 +1,71 @@
+package contender
+
+import "github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+
+// grouper assembles orders from join rows ordered by order id, so an order
+// is complete as soon as a row of the next one arrives.
+type grouper struct {
+	order   Order
+	started bool
+}
+
+// addPair adds a row split into its order and item, and returns the order
+// the row completed, if any.
+func (g *grouper) addPair(order model.Orders, item model.OrderItems) (Order, bool) {
+	var (
+		done      Order
+		completed = g.started && g.order.ID != order.ID
+	)
+	if completed || !g.started {
+		done = g.order
+		g.order = Order{Orders: order}
+		g.started = true
+	}
+	g.order.Itens = append(g.order.Itens, item)
+	return done, completed
+}
+
+// last returns the order still being assembled once the rows run out.
+func (g *grouper) last() (Order, bool) {
+	return g.order, g.started
+}
+
+// cursor is the part of *sql.Rows and pgx.Rows the stream helpers use.
+type cursor interface {
+	Next() bool
+	Err() error
+}
+
+// streamPairs yields the orders assembled from rows, which scan decodes
+// one at a time into an order and an item. The caller closes rows.
+func streamPairs(rows cursor, scan func() (model.Orders, model.OrderItems, error), yield func(Order, error) bool) {
+	var g grouper
+	for rows.Next() {
+		order, item, err := scan()
+		if err != nil {
+			yield(Order{}, err)
+			return
+		}
+		if done, ok := g.addPair(order, item); ok && !yield(done, nil) {
+			return
+		}
+	}
+	if err := rows.Err(); err != nil {
+		yield(Order{}, err)
+		return
+	}
+	if order, ok := g.last(); ok {
+		yield(order, nil)
+	}
+}
+
+// streamJoin is streamPairs for rows scan decodes into a joinRow.
+func streamJoin(rows cursor, scan func(row *joinRow) error, yield func(Order, error) bool) {
+	streamPairs(rows, func() (model.Orders, model.OrderItems, error) {
+		var row joinRow
+		if err := scan(&row); err != nil {
+			return model.Orders{}, model.OrderItems{}, err
+		}
+		return row.order().Orders, row.item(), nil
+	}, yield)
+}
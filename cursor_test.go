@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+)
+
+var fetchSizes = flag.String("fetch-sizes", "100,1000,10000,50000", "FETCH sizes swept by BenchmarkCursor")
+
+// BenchmarkCursor reads the whole join through a server-side cursor with
+// every contender implementing contender.Cursor, for each FETCH size, and
+// reports the peak heap next to the usual metrics. Add -wire-stats to see
+// the round trips each size costs.
+func BenchmarkCursor(b *testing.B) {
+	if !liveDatabase() {
+		b.Skip("BenchmarkCursor declares cursors and cannot run in a replay mode")
+	}
+
+	for _, size := range parseInts(b, *fetchSizes) {
+		b.Run(fmt.Sprintf("fetch=%d", size), func(b *testing.B) {
+			for _, name := range contender.Names() {
+				b.Run(name, func(b *testing.B) {
+					c := getContender(b, name)
+					cursor, ok := c.(contender.Cursor)
+					if !ok {
+						b.Skipf("%s does not read through cursors", name)
+					}
+
+					heap := sampleHeap()
+					wire := wireSnapshot()
+					b.ResetTimer()
+					benchmarkCursor(b, cursor, size)
+					b.StopTimer()
+					b.ReportMetric(float64(heap.stop()), "peak-heap-B")
+					reportWire(b, wire)
+				})
+			}
+		})
+	}
+}
+
+func benchmarkCursor(b *testing.B, c contender.Cursor, size int) {
+	shape := datasetShape(b)
+
+	for range b.N {
+		orders, err := c.FetchAllCursor(b.Context(), size)
+		if err != nil {
+			b.Fatalf("query failed: %v", err)
+		}
+
+		checkOrders(b, orders, shape)
+	}
+}
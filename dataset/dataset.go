@@ -0,0 +1,225 @@
+// Package dataset seeds the orders and order_items tables with a
+// configurable shape and records that shape in dataset_metadata, so the
+// benchmarks know what every contender should return.
+package dataset
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Config describes the dataset to generate.
+type Config struct {
+	// Orders is the number of orders.
+	Orders int
+	// Items draws the number of items of each order.
+	Items Distribution
+	// Seed makes the generated data reproducible.
+	Seed uint64
+	// CustomerNameLength is the length of the random customer names. Zero
+	// picks names from a fixed list, as the init migration does.
+	CustomerNameLength int
+	// ProductNameLength is the length of the random product names. Zero
+	// picks names from a fixed list, as the init migration does.
+	ProductNameLength int
+}
+
+// Shape is what the seeded tables contain, as read back by the benchmarks.
+type Shape struct {
+	Orders          int
+	OrderItems      int
+	OrdersWithItems int
+	// SampleOrderID is the lowest order id that has items; SampleOrderItems
+	// is how many it has. Single-order benchmarks look this order up.
+	SampleOrderID    int32
+	SampleOrderItems int
+}
+
+// Metadata is one row of dataset_metadata.
+type Metadata struct {
+	Shape
+	Distribution       string
+	Seed               uint64
+	CustomerNameLength int
+	ProductNameLength  int
+}
+
+var (
+	customerNames = []string{"John Doe", "Jane Smith", "Bob Johnson", "Alice Brown", "Charlie Wilson", "Diana Davis", "Eve Miller", "Frank Garcia", "Grace Lee", "Henry Martinez"}
+	productNames  = []string{"Laptop", "Mouse", "Keyboard", "Monitor", "Webcam", "Speaker", "Headphones", "Tablet", "Phone", "Charger"}
+)
+
+// Seed replaces the content of orders and order_items with a dataset
+// generated from cfg, using COPY, and records its metadata. Everything
+// runs in one transaction.
+func Seed(ctx context.Context, conn *pgx.Conn, cfg Config) (Metadata, error) {
+	r := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	nextItems := cfg.Items.Sampler(r)
+
+	meta := Metadata{
+		Distribution:       cfg.Items.String(),
+		Seed:               cfg.Seed,
+		CustomerNameLength: cfg.CustomerNameLength,
+		ProductNameLength:  cfg.ProductNameLength,
+	}
+	meta.Orders = cfg.Orders
+
+	itemCounts := make([]int32, cfg.Orders)
+	for i := range itemCounts {
+		n := nextItems()
+		itemCounts[i] = int32(n)
+		meta.OrderItems += n
+		if n > 0 {
+			meta.OrdersWithItems++
+			if meta.SampleOrderID == 0 {
+				meta.SampleOrderID = int32(i + 1)
+				meta.SampleOrderItems = n
+			}
+		}
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return Metadata{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "TRUNCATE order_items, orders RESTART IDENTITY"); err != nil {
+		return Metadata{}, fmt.Errorf("dataset: truncate: %w", err)
+	}
+
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	orderID := 0
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"orders"}, []string{"id", "customer_name", "created_at"},
+		pgx.CopyFromFunc(func() ([]any, error) {
+			if orderID == cfg.Orders {
+				return nil, nil
+			}
+			orderID++
+			createdAt := base.Add(time.Duration(r.Int64N(int64(365 * 24 * time.Hour)))).Truncate(time.Microsecond)
+			return []any{int32(orderID), name(r, customerNames, cfg.CustomerNameLength), createdAt}, nil
+		}))
+	if err != nil {
+		return Metadata{}, fmt.Errorf("dataset: copy orders: %w", err)
+	}
+
+	itemID, itemOrder, itemLeft := 0, 0, 0
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"order_items"}, []string{"id", "order_id", "product_name", "price", "quantity"},
+		pgx.CopyFromFunc(func() ([]any, error) {
+			for itemLeft == 0 {
+				if itemOrder == cfg.Orders {
+					return nil, nil
+				}
+				itemLeft = int(itemCounts[itemOrder])
+				itemOrder++
+			}
+			itemLeft--
+			itemID++
+			price := float64(1000+r.IntN(99000)) / 100 // 10.00 to 999.99
+			quantity := int32(1 + r.IntN(4))
+			return []any{int32(itemID), int32(itemOrder), name(r, productNames, cfg.ProductNameLength), price, quantity}, nil
+		}))
+	if err != nil {
+		return Metadata{}, fmt.Errorf("dataset: copy order items: %w", err)
+	}
+
+	for _, stmt := range []string{
+		"SELECT setval('orders_id_seq', GREATEST((SELECT MAX(id) FROM orders), 1))",
+		"SELECT setval('order_items_id_seq', GREATEST((SELECT MAX(id) FROM order_items), 1))",
+		"DELETE FROM dataset_metadata",
+	} {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return Metadata{}, fmt.Errorf("dataset: %s: %w", stmt, err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO dataset_metadata (
+			orders, order_items, orders_with_items, sample_order_id, sample_order_items,
+			distribution, seed, customer_name_length, product_name_length
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		meta.Orders, meta.OrderItems, meta.OrdersWithItems, meta.SampleOrderID, meta.SampleOrderItems,
+		meta.Distribution, int64(meta.Seed), meta.CustomerNameLength, meta.ProductNameLength,
+	)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("dataset: insert metadata: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Metadata{}, err
+	}
+
+	if _, err := conn.Exec(ctx, "ANALYZE orders, order_items"); err != nil {
+		return Metadata{}, fmt.Errorf("dataset: analyze: %w", err)
+	}
+	return meta, nil
+}
+
+// ReadShape reads the shape of the seeded dataset from dataset_metadata.
+func ReadShape(ctx context.Context, db *sql.DB) (Shape, error) {
+	var shape Shape
+	err := db.QueryRowContext(ctx, `
+		SELECT orders, order_items, orders_with_items, sample_order_id, sample_order_items
+		FROM dataset_metadata`,
+	).Scan(&shape.Orders, &shape.OrderItems, &shape.OrdersWithItems, &shape.SampleOrderID, &shape.SampleOrderItems)
+	if err != nil {
+		return Shape{}, fmt.Errorf("dataset: read dataset_metadata: %w", err)
+	}
+	return shape, nil
+}
+
+// ReadMetadata reads the full dataset_metadata row.
+func ReadMetadata(ctx context.Context, db *sql.DB) (Metadata, error) {
+	var (
+		meta Metadata
+		seed int64
+	)
+	err := db.QueryRowContext(ctx, `
+		SELECT orders, order_items, orders_with_items, sample_order_id, sample_order_items,
+			distribution, seed, customer_name_length, product_name_length
+		FROM dataset_metadata`,
+	).Scan(
+		&meta.Orders, &meta.OrderItems, &meta.OrdersWithItems, &meta.SampleOrderID, &meta.SampleOrderItems,
+		&meta.Distribution, &seed, &meta.CustomerNameLength, &meta.ProductNameLength,
+	)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("dataset: read dataset_metadata: %w", err)
+	}
+	meta.Seed = uint64(seed)
+	return meta, nil
+}
+
+// Config returns the configuration that generates a dataset of the same
+// shape as m.
+func (m Metadata) Config() (Config, error) {
+	dist, err := ParseDistribution(m.Distribution)
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{
+		Orders:             m.Orders,
+		Items:              dist,
+		Seed:               m.Seed,
+		CustomerNameLength: m.CustomerNameLength,
+		ProductNameLength:  m.ProductNameLength,
+	}, nil
+}
+
+// name returns a random entry of names, or a random lowercase string of
+// length n when n > 0.
+func name(r *rand.Rand, names []string, n int) string {
+	if n <= 0 {
+		return names[r.IntN(len(names))]
+	}
+
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = 'a' + byte(r.IntN(26))
+	}
+	return string(b)
+}
@@ -0,0 +1,100 @@
+package dataset
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Distribution draws the number of items of each order.
+type Distribution interface {
+	// Sampler returns a function drawing the item count of the next order
+	// from r.
+	Sampler(r *rand.Rand) func() int
+	// String returns the distribution in the form ParseDistribution
+	// accepts.
+	String() string
+}
+
+// ParseDistribution parses one of:
+//
+//	fixed:N          every order has N items
+//	uniform:MIN:MAX  item counts uniformly distributed in [MIN, MAX]
+//	zipf:S:MAX       Zipf distributed item counts in [0, MAX] with exponent S > 1
+func ParseDistribution(s string) (Distribution, error) {
+	kind, args, _ := strings.Cut(s, ":")
+	params := strings.Split(args, ":")
+
+	switch kind {
+	case "fixed":
+		if len(params) != 1 {
+			break
+		}
+		n, err := strconv.Atoi(params[0])
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("dataset: invalid item count in %q", s)
+		}
+		return Fixed(n), nil
+
+	case "uniform":
+		if len(params) != 2 {
+			break
+		}
+		lo, err1 := strconv.Atoi(params[0])
+		hi, err2 := strconv.Atoi(params[1])
+		if err1 != nil || err2 != nil || lo < 0 || hi < lo {
+			return nil, fmt.Errorf("dataset: invalid range in %q", s)
+		}
+		return Uniform{Min: lo, Max: hi}, nil
+
+	case "zipf":
+		if len(params) != 2 {
+			break
+		}
+		exp, err1 := strconv.ParseFloat(params[0], 64)
+		hi, err2 := strconv.Atoi(params[1])
+		if err1 != nil || err2 != nil || exp <= 1 || hi < 0 {
+			return nil, fmt.Errorf("dataset: invalid zipf parameters in %q", s)
+		}
+		return Zipf{S: exp, Max: hi}, nil
+	}
+
+	return nil, fmt.Errorf("dataset: unknown distribution %q, want fixed:N, uniform:MIN:MAX or zipf:S:MAX", s)
+}
+
+// Fixed gives every order the same number of items.
+type Fixed int
+
+func (f Fixed) Sampler(*rand.Rand) func() int {
+	return func() int { return int(f) }
+}
+
+func (f Fixed) String() string { return fmt.Sprintf("fixed:%d", int(f)) }
+
+// Uniform draws item counts uniformly from [Min, Max].
+type Uniform struct {
+	Min, Max int
+}
+
+func (u Uniform) Sampler(r *rand.Rand) func() int {
+	return func() int { return u.Min + r.IntN(u.Max-u.Min+1) }
+}
+
+func (u Uniform) String() string { return fmt.Sprintf("uniform:%d:%d", u.Min, u.Max) }
+
+// Zipf draws item counts from [0, Max] so that most orders have few items
+// and a long tail has many.
+type Zipf struct {
+	S   float64
+	Max int
+}
+
+func (z Zipf) Sampler(r *rand.Rand) func() int {
+	zipf := rand.NewZipf(r, z.S, 1, uint64(z.Max))
+	return func() int { return int(zipf.Uint64()) }
+}
+
+func (z Zipf) String() string {
+	return fmt.Sprintf("zipf:%s:%d", strconv.FormatFloat(z.S, 'g', -1, 64), z.Max)
+}
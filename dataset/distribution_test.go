@@ -0,0 +1,42 @@
+package dataset
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestParseDistribution(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max int
+	}{
+		{in: "fixed:5", min: 5, max: 5},
+		{in: "uniform:0:200", min: 0, max: 200},
+		{in: "zipf:1.5:200", min: 0, max: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			dist, err := ParseDistribution(tt.in)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			if got := dist.String(); got != tt.in {
+				t.Errorf("expected String() %q, got %q", tt.in, got)
+			}
+
+			next := dist.Sampler(rand.New(rand.NewPCG(1, 1)))
+			for range 1000 {
+				if n := next(); n < tt.min || n > tt.max {
+					t.Fatalf("expected item counts in [%d, %d], got %d", tt.min, tt.max, n)
+				}
+			}
+		})
+	}
+
+	for _, in := range []string{"", "fixed", "fixed:-1", "uniform:5:1", "zipf:1:10", "normal:5"} {
+		if _, err := ParseDistribution(in); err == nil {
+			t.Errorf("expected an error for %q", in)
+		}
+	}
+}
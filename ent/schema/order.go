@@ -0,0 +1,37 @@
+// Package schema holds the ent schema of the orders and order_items
+// tables created by migration/000001_init.up.sql. The client generated
+// from it lives in .gen/ent; regenerate it with `make ent`.
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Order holds the schema of the orders table.
+type Order struct {
+	ent.Schema
+}
+
+func (Order) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "orders"},
+	}
+}
+
+func (Order) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int32("id"),
+		field.String("customer_name").MaxLen(255),
+		field.Time("created_at").Optional().Nillable(),
+	}
+}
+
+func (Order) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("items", OrderItem.Type),
+	}
+}
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// OrderItem holds the schema of the order_items table.
+type OrderItem struct {
+	ent.Schema
+}
+
+func (OrderItem) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "order_items"},
+	}
+}
+
+func (OrderItem) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int32("id"),
+		field.Int32("order_id").Optional().Nillable(),
+		field.String("product_name").MaxLen(255),
+		field.Float("price").SchemaType(map[string]string{dialect.Postgres: "decimal(10,2)"}),
+		field.Int32("quantity").Optional().Nillable(),
+	}
+}
+
+func (OrderItem) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("order", Order.Type).Ref("items").Field("order_id").Unique(),
+	}
+}
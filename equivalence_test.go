@@ -0,0 +1,221 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+)
+
+// referenceContender is the hand-written scan loop every other contender is
+// compared against.
+const referenceContender = "Pq"
+
+// TestContendersEquivalent checks that every contender returns exactly the
+// rows the reference contender returns. It runs before the benchmarks, so
+// `go test -bench` stops when a contender maps the data incorrectly.
+func TestContendersEquivalent(t *testing.T) {
+	if err := env.DB.PingContext(t.Context()); err != nil {
+		t.Skipf("postgres is not reachable: %v", err)
+	}
+
+	shape := datasetShape(t)
+	ref := getContender(t, referenceContender)
+
+	want, err := ref.FetchAll(t.Context())
+	if err != nil {
+		t.Fatalf("%s: query failed: %v", referenceContender, err)
+	}
+	want = normalize(want)
+	if len(want) == 0 {
+		t.Fatalf("%s: returned no orders", referenceContender)
+	}
+
+	// Floors return no orders, so only their row counts are checked.
+	items := 0
+	for _, order := range want {
+		items += len(order.Itens)
+	}
+	for _, name := range contender.FloorNames() {
+		t.Run(name, func(t *testing.T) {
+			f := getFloor(t, name)
+
+			t.Run("All", func(t *testing.T) {
+				rows, err := f.DrainAll(t.Context())
+				if err != nil {
+					t.Fatalf("query failed: %v", err)
+				}
+				if rows != items {
+					t.Errorf("expected %d rows, got %d", items, rows)
+				}
+			})
+
+			t.Run("OneResult", func(t *testing.T) {
+				rows, err := f.DrainOne(t.Context(), shape.SampleOrderID)
+				if err != nil {
+					t.Fatalf("query failed: %v", err)
+				}
+				if rows != len(want[0].Itens) {
+					t.Errorf("expected %d rows, got %d", len(want[0].Itens), rows)
+				}
+			})
+		})
+	}
+
+	for _, name := range contender.Names() {
+		if name == referenceContender {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			c := getContender(t, name)
+
+			t.Run("All", func(t *testing.T) {
+				skipBounded(t, c, shape)
+
+				got, err := c.FetchAll(t.Context())
+				if err != nil {
+					t.Fatalf("query failed: %v", err)
+				}
+				compareOrders(t, want, normalize(got))
+			})
+
+			t.Run("OneResult", func(t *testing.T) {
+				got, err := c.FetchOne(t.Context(), shape.SampleOrderID)
+				if err != nil {
+					t.Fatalf("query failed: %v", err)
+				}
+				// The sample order is the lowest id with items, so want[0].
+				compareOrders(t, want[:1], normalize([]contender.Order{got}))
+			})
+
+			t.Run("Cursor", func(t *testing.T) {
+				cursor, ok := c.(contender.Cursor)
+				if !ok {
+					t.Skipf("%s does not read through cursors", name)
+				}
+				if !liveDatabase() {
+					t.Skip("cursors cannot be replayed")
+				}
+
+				// A prime FETCH size splits orders across fetches for most
+				// item counts.
+				got, err := cursor.FetchAllCursor(t.Context(), 997)
+				if err != nil {
+					t.Fatalf("query failed: %v", err)
+				}
+				compareOrders(t, want, normalize(got))
+			})
+
+			t.Run("Stream", func(t *testing.T) {
+				s, ok := c.(contender.Streamer)
+				if !ok {
+					t.Skipf("%s cannot stream", name)
+				}
+
+				var got []contender.Order
+				for order, err := range s.Stream(t.Context()) {
+					if err != nil {
+						t.Fatalf("stream failed: %v", err)
+					}
+					if len(got) > 0 && order.ID <= got[len(got)-1].ID {
+						t.Fatalf("order %d streamed after order %d", order.ID, got[len(got)-1].ID)
+					}
+					got = append(got, order)
+				}
+				compareOrders(t, want, normalize(got))
+			})
+		})
+	}
+}
+
+// normalize drops orders without items, sorts orders and their items by id
+// and moves timestamps to UTC, so results only differ when the mapped values
+// differ. Orders without items are dropped because the join-based
+// contenders never see them while GORM's Preload returns them.
+func normalize(orders []contender.Order) []contender.Order {
+	out := make([]contender.Order, 0, len(orders))
+	for _, order := range orders {
+		if len(order.Itens) == 0 {
+			continue
+		}
+		if order.CreatedAt != nil {
+			createdAt := order.CreatedAt.UTC()
+			order.CreatedAt = &createdAt
+		}
+		order.Itens = slices.SortedFunc(slices.Values(order.Itens), func(a, b model.OrderItems) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+		out = append(out, order)
+	}
+
+	slices.SortFunc(out, func(a, b contender.Order) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return out
+}
+
+// compareOrders reports the first order that differs between want and got.
+func compareOrders(t *testing.T, want, got []contender.Order) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("expected %d orders, got %d", len(want), len(got))
+	}
+
+	for i := range min(len(want), len(got)) {
+		if diff := diffOrder(want[i], got[i]); diff != "" {
+			t.Fatalf("order #%d (id %d) differs:\n%s", i, want[i].ID, diff)
+		}
+	}
+}
+
+// diffOrder returns one line per field that differs between want and got,
+// or "" when they are equal.
+func diffOrder(want, got contender.Order) string {
+	var d differ
+	d.field("id", want.ID, got.ID)
+	d.field("customer_name", want.CustomerName, got.CustomerName)
+	d.field("created_at", formatTime(want.CreatedAt), formatTime(got.CreatedAt))
+	d.field("len(itens)", len(want.Itens), len(got.Itens))
+
+	for i := range min(len(want.Itens), len(got.Itens)) {
+		w, g := want.Itens[i], got.Itens[i]
+		prefix := fmt.Sprintf("itens[%d].", i)
+		d.field(prefix+"id", w.ID, g.ID)
+		d.field(prefix+"order_id", formatPtr(w.OrderID), formatPtr(g.OrderID))
+		d.field(prefix+"product_name", w.ProductName, g.ProductName)
+		d.field(prefix+"price", w.Price, g.Price)
+		d.field(prefix+"quantity", formatPtr(w.Quantity), formatPtr(g.Quantity))
+	}
+	return d.String()
+}
+
+type differ struct {
+	strings.Builder
+}
+
+func (d *differ) field(name string, want, got any) {
+	if want != got {
+		fmt.Fprintf(d, "\t%s: want %v, got %v\n", name, want, got)
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatPtr(p *int32) string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprint(*p)
+}
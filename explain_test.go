@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+)
+
+// BenchmarkServerTime runs the FetchAll statement of every contender
+// implementing contender.Explainer under EXPLAIN ANALYZE and reports the
+// execution time Postgres measured, so the cost of building the JSON on
+// the server can be set against the plain join's and against the client
+// time BenchmarkContenders reports. ns/op also holds the EXPLAIN round
+// trip and is not meaningful here.
+func BenchmarkServerTime(b *testing.B) {
+	if !liveDatabase() {
+		b.Skip("BenchmarkServerTime measures Postgres and cannot run in a replay mode")
+	}
+
+	for _, name := range contender.Names() {
+		b.Run(name, func(b *testing.B) {
+			c := getContender(b, name)
+			explainer, ok := c.(contender.Explainer)
+			if !ok {
+				b.Skipf("%s does not run a single statement", name)
+			}
+
+			var total time.Duration
+			for range b.N {
+				d, err := explainer.ExplainAll(b.Context())
+				if err != nil {
+					b.Fatalf("explain failed: %v", err)
+				}
+				total += d
+			}
+			b.ReportMetric(float64(total.Nanoseconds())/float64(b.N), "server-ns/op")
+		})
+	}
+}
@@ -1,663 +1,407 @@
 package main
 
 import (
+	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
 	"os"
+	"sync"
 	"testing"
-	"time"
-
-	. "github.com/go-jet/jet/v2/postgres"
-	"github.com/jackskj/carta"
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
-	"github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/model"
-	. "github.com/lucasHSantiago/go-select-benchmark/.gen/order/public/table"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lib/pq"
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+	"github.com/lucasHSantiago/go-select-benchmark/dataset"
+	"github.com/lucasHSantiago/go-select-benchmark/pgproxy"
+	"github.com/lucasHSantiago/go-select-benchmark/pgreplay"
+	"github.com/lucasHSantiago/go-select-benchmark/replay"
 )
 
-var db *sql.DB
+const connStr = "host=localhost port=5432 user=postgres password=admin dbname=order sslmode=disable"
+
+var (
+	env        contender.Env
+	contenders = map[string]contender.Contender{}
+	// wireProxy carries every connection when -wire-stats or -network is
+	// set.
+	wireProxy *pgproxy.Proxy
+)
+
+var (
+	recordPath     = flag.String("record", "", "record every query result the contenders read into this replay fixture")
+	replayPath     = flag.String("replay", "", "serve query results from this replay fixture instead of Postgres")
+	wireRecordPath = flag.String("wire-record", "", "record every statement sent through the wire replay server into this fixture")
+	wireReplayPath = flag.String("wire-replay", "", "serve every connection from a wire replay server loaded from this fixture")
+	wireStats      = flag.Bool("wire-stats", false, "route connections through a counting proxy and report queries, round trips and bytes per op")
+	network        = flag.String("network", "", "route connections through a proxy simulating this network: local, lan, az, region or RTT[:JITTER[:MBIT]]")
+	poolConfig     = flag.String("pool", "0:2:0", "pool configuration applied to every contender, as OPEN:IDLE:LIFETIME")
+)
 
 func TestMain(m *testing.M) {
-	const connStr = "user=postgres password=admin dbname=order sslmode=disable"
-	var err error
+	flag.Parse()
 
-	db, err = sql.Open("postgres", connStr)
-	if err != nil {
-		panic("failed to open db: " + err.Error())
-	}
-	defer db.Close()
+	var (
+		fixture     *replay.Fixture
+		wireFixture *pgreplay.Fixture
+		server      *pgreplay.Server
+	)
+	switch {
+	case *replayPath != "":
+		db, err := sql.Open(replay.DriverName, *replayPath)
+		if err != nil {
+			panic("failed to open replay db: " + err.Error())
+		}
+		if err := db.Ping(); err != nil {
+			panic("failed to load replay fixture: " + err.Error())
+		}
 
-	db.SetMaxOpenConns(0)
-	db.SetMaxIdleConns(0)
+		env = contender.Env{DB: db, GormDialector: replay.NewDialector(db)}
 
-	os.Exit(m.Run())
-}
+	case *recordPath != "":
+		fixture = replay.NewFixture()
+		db := sql.OpenDB(replay.NewRecorder(&pq.Driver{}, connStr, fixture))
 
-func BenchmarkJet(b *testing.B) {
-	stmt := SELECT(
-		Orders.AllColumns,
-		OrderItems.AllColumns,
-	).FROM(
-		Orders.
-			INNER_JOIN(OrderItems, Orders.ID.EQ(OrderItems.OrderID)),
-	).ORDER_BY(Orders.ID.ASC())
-
-	type dest []struct {
-		model.Orders
-		Itens []struct {
-			model.OrderItems
-		}
-	}
+		env = contender.Env{DB: db, GormDialector: replay.NewDialector(db)}
 
-	for range b.N {
-		dest := make(dest, 0, 50000)
-		err := stmt.QueryContext(b.Context(), db, &dest)
-		if err != nil {
-			b.Fatalf("query failed: %v", err)
-		}
+	default:
+		dsn := connStr
 
-		if len(dest) != 50000 {
-			b.Fatalf("expected 50000 results, got %d", len(dest))
-		}
+		switch {
+		case *wireReplayPath != "":
+			var err error
+			if wireFixture, err = pgreplay.LoadFixture(*wireReplayPath); err != nil {
+				panic("failed to load wire fixture: " + err.Error())
+			}
+			server = pgreplay.NewServer(wireFixture)
 
-		if len(dest[0].Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(dest[0].Itens))
+		case *wireRecordPath != "":
+			wireFixture = pgreplay.NewFixture()
+			server = pgreplay.NewRecordingServer(wireFixture, connStr)
 		}
-	}
-}
 
-func BenchmarkJetOneResult(b *testing.B) {
-	stmt := SELECT(
-		Orders.AllColumns,
-		OrderItems.AllColumns,
-	).FROM(
-		Orders.
-			INNER_JOIN(OrderItems, Orders.ID.EQ(OrderItems.OrderID)),
-	).WHERE(
-		Orders.ID.EQ(Int32(1)),
-	).ORDER_BY(
-		Orders.ID.ASC(),
-	)
+		upstream := "localhost:5432"
+		if server != nil {
+			if err := server.Listen("127.0.0.1:0"); err != nil {
+				panic("failed to start wire replay server: " + err.Error())
+			}
+			upstream = server.Addr().String()
+			dsn = localDSN(server.Addr())
+		}
 
-	type dest struct {
-		model.Orders
-		Itens []struct {
-			model.OrderItems
+		if *wireStats || *network != "" {
+			wireProxy = pgproxy.New(upstream)
+			if *network != "" {
+				profile, err := pgproxy.ParseProfile(*network)
+				if err != nil {
+					panic(err.Error())
+				}
+				wireProxy.SetProfile(profile)
+			}
+			if err := wireProxy.Listen("127.0.0.1:0"); err != nil {
+				panic("failed to start proxy: " + err.Error())
+			}
+			dsn = localDSN(wireProxy.Addr())
 		}
-	}
 
-	for range b.N {
-		dest := dest{}
-		err := stmt.QueryContext(b.Context(), db, &dest)
+		cfg, err := contender.ParsePoolConfig(*poolConfig)
 		if err != nil {
-			b.Fatalf("query failed: %v", err)
+			panic(err.Error())
 		}
 
-		if len(dest.Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(dest.Itens))
+		if env, err = openEnv(dsn, cfg); err != nil {
+			panic(err.Error())
 		}
 	}
-}
 
-func BenchmarkSqlx(b *testing.B) {
-	dbx := sqlx.NewDb(db, "postgres")
+	code := m.Run()
 
-	type OrderWithItems struct {
-		model.Orders
-		Itens []model.OrderItems
+	for _, c := range contenders {
+		if closer, ok := c.(io.Closer); ok {
+			closer.Close()
+		}
 	}
 
-	type results []struct {
-		ID           int32      `db:"orders.id"`
-		CustomerName string     `db:"orders.customer_name"`
-		CreatedAt    *time.Time `db:"orders.created_at"`
-		OrderItemID  int32      `db:"order_items.id"`
-		OrderID      *int32     `db:"order_items.order_id"`
-		ProductName  string     `db:"order_items.product_name"`
-		Price        float64    `db:"order_items.price"`
-		Quantity     *int32     `db:"order_items.quantity"`
+	if env.Pool != nil {
+		env.Pool.Close()
 	}
+	env.DB.Close()
 
-	const query = `
-	SELECT orders.id AS "orders.id",
-		orders.customer_name AS "orders.customer_name",
-		orders.created_at AS "orders.created_at",
-		order_items.id AS "order_items.id",
-		order_items.order_id AS "order_items.order_id",
-		order_items.product_name AS "order_items.product_name",
-		order_items.price AS "order_items.price",
-		order_items.quantity AS "order_items.quantity"
-	FROM public.orders
-		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-	ORDER BY orders.id ASC;
-	`
+	if wireProxy != nil {
+		wireProxy.Close()
+	}
+	if server != nil {
+		server.Close()
+	}
 
-	for range b.N {
-		results := make(results, 0, 50000)
-		err := dbx.Select(&results, query)
-		if err != nil {
-			b.Fatalf("query failed: %v", err)
+	if code == 0 {
+		if fixture != nil {
+			if err := fixture.Save(*recordPath); err != nil {
+				panic("failed to save replay fixture: " + err.Error())
+			}
 		}
-
-		var orders []OrderWithItems
-		orderIdx := make(map[int32]int)
-		for _, row := range results {
-			idx, ok := orderIdx[row.ID]
-			if !ok {
-				orders = append(orders, OrderWithItems{
-					Orders: model.Orders{
-						ID:           row.ID,
-						CustomerName: row.CustomerName,
-						CreatedAt:    row.CreatedAt,
-					},
-				})
-				idx = len(orders) - 1
-				orderIdx[row.ID] = idx
+		if wireFixture != nil && *wireRecordPath != "" {
+			if err := wireFixture.Save(*wireRecordPath); err != nil {
+				panic("failed to save wire fixture: " + err.Error())
 			}
-			orders[idx].Itens = append(orders[idx].Itens, model.OrderItems{
-				ID:          row.OrderItemID,
-				OrderID:     row.OrderID,
-				ProductName: row.ProductName,
-				Price:       row.Price,
-				Quantity:    row.Quantity,
-			})
 		}
+	}
 
-		if len(orders) != 50000 {
-			b.Fatalf("expected 50000 results, got %d", len(orders))
-		}
+	os.Exit(code)
+}
 
-		if len(orders[0].Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(orders[0].Itens))
-		}
-	}
+// localDSN returns connStr pointed at a server listening on addr.
+func localDSN(addr net.Addr) string {
+	port := addr.(*net.TCPAddr).Port
+	return fmt.Sprintf("host=127.0.0.1 port=%d user=postgres password=admin dbname=order sslmode=disable", port)
 }
 
-func BenchmarkSqlxOneResult(b *testing.B) {
-	dbx := sqlx.NewDb(db, "postgres")
+// openEnv opens a lib/pq database/sql pool and a pgxpool on dsn, both
+// sized by cfg, and passes cfg on to contenders that open their own.
+func openEnv(dsn string, cfg contender.PoolConfig) (contender.Env, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return contender.Env{}, fmt.Errorf("failed to open db: %w", err)
+	}
+	cfg.ApplyDB(db)
 
-	type OrderWithItems struct {
-		model.Orders
-		Itens []model.OrderItems
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		db.Close()
+		return contender.Env{}, fmt.Errorf("failed to parse pgx pool config: %w", err)
 	}
+	cfg.ApplyPgx(poolCfg)
 
-	type results []struct {
-		ID           int32      `db:"orders.id"`
-		CustomerName string     `db:"orders.customer_name"`
-		CreatedAt    *time.Time `db:"orders.created_at"`
-		OrderItemID  int32      `db:"order_items.id"`
-		OrderID      *int32     `db:"order_items.order_id"`
-		ProductName  string     `db:"order_items.product_name"`
-		Price        float64    `db:"order_items.price"`
-		Quantity     *int32     `db:"order_items.quantity"`
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		db.Close()
+		return contender.Env{}, fmt.Errorf("failed to open pgx pool: %w", err)
 	}
 
-	const query = `
-	SELECT orders.id AS "orders.id",
-		orders.customer_name AS "orders.customer_name",
-		orders.created_at AS "orders.created_at",
-		order_items.id AS "order_items.id",
-		order_items.order_id AS "order_items.order_id",
-		order_items.product_name AS "order_items.product_name",
-		order_items.price AS "order_items.price",
-		order_items.quantity AS "order_items.quantity"
-	FROM public.orders
-		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-	WHERE orders.id = 1
-	ORDER BY orders.id ASC;
-	`
+	return contender.Env{DB: db, Pool: pool, DSN: dsn, PoolConfig: &cfg}, nil
+}
 
-	for range b.N {
-		results := make(results, 0, 5)
-		err := dbx.Select(&results, query)
-		if err != nil {
-			b.Fatalf("query failed: %v", err)
-		}
+var (
+	shape     dataset.Shape
+	shapeErr  error
+	shapeOnce sync.Once
+)
 
-		var order OrderWithItems
-		orderIdx := make(map[int32]int)
-		for _, row := range results {
-			idx, ok := orderIdx[row.ID]
-			if !ok {
-				order = OrderWithItems{
-					Orders: model.Orders{
-						ID:           row.ID,
-						CustomerName: row.CustomerName,
-						CreatedAt:    row.CreatedAt,
-					},
-				}
-				orderIdx[row.ID] = idx
-			}
-			order.Itens = append(order.Itens, model.OrderItems{
-				ID:          row.OrderItemID,
-				OrderID:     row.OrderID,
-				ProductName: row.ProductName,
-				Price:       row.Price,
-				Quantity:    row.Quantity,
-			})
-		}
+// datasetShape returns the shape of the seeded dataset, read once from
+// dataset_metadata.
+func datasetShape(tb testing.TB) dataset.Shape {
+	tb.Helper()
 
-		if len(order.Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(order.Itens))
-		}
+	shapeOnce.Do(func() {
+		shape, shapeErr = dataset.ReadShape(context.Background(), env.DB)
+	})
+	if shapeErr != nil {
+		tb.Fatalf("%v (run make migrateup)", shapeErr)
 	}
+	return shape
 }
 
-func BenchmarkCarta(b *testing.B) {
-	type orders []struct {
-		ID           int32      `db:"orders.id"`
-		CustomerName string     `db:"orders.customer_name"`
-		CreatedAt    *time.Time `db:"orders.created_at"`
-		Itens        []struct {
-			OrderItemID int32   `db:"order_items.id"`
-			OrderID     *int32  `db:"order_items.order_id"`
-			ProductName string  `db:"order_items.product_name"`
-			Price       float64 `db:"order_items.price"`
-			Quantity    *int32  `db:"order_items.quantity"`
-		}
-	}
+// getContender returns the contender registered under name, building it
+// on first use. Contenders that do not support env are skipped.
+func getContender(tb testing.TB, name string) contender.Contender {
+	tb.Helper()
 
-	const query = `
-	SELECT orders.id AS "orders.id",
-		orders.customer_name AS "orders.customer_name",
-		orders.created_at AS "orders.created_at",
-		order_items.id AS "order_items.id",
-		order_items.order_id AS "order_items.order_id",
-		order_items.product_name AS "order_items.product_name",
-		order_items.price AS "order_items.price",
-		order_items.quantity AS "order_items.quantity"
-	FROM public.orders
-		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-	ORDER BY orders.id ASC;
-	`
+	if c, ok := contenders[name]; ok {
+		return c
+	}
 
-	for range b.N {
-		rows, err := db.Query(query)
-		if err != nil {
-			b.Fatalf("query failed: %v", err)
-		}
+	c, err := contender.New(context.Background(), name, env)
+	if errors.Is(err, contender.ErrUnsupported) {
+		tb.Skipf("%s: unsupported in this environment", name)
+	}
+	if err != nil {
+		tb.Fatalf("%s: failed to initialize: %v", name, err)
+	}
 
-		orders := make(orders, 0, 50000)
-		err = carta.Map(rows, &orders)
-		if err != nil {
-			b.Fatalf("mapping failed: %v", err)
-		}
+	contenders[name] = c
+	return c
+}
 
-		if len(orders) != 50000 {
-			b.Fatalf("expected 50000 results, got %d", len(orders))
-		}
+// getFloor returns the floor registered under name. Floors that do not
+// support env are skipped.
+func getFloor(tb testing.TB, name string) contender.Floor {
+	tb.Helper()
 
-		if len(orders[0].Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(orders[0].Itens))
-		}
+	f, err := contender.NewFloor(context.Background(), name, env)
+	if errors.Is(err, contender.ErrUnsupported) {
+		tb.Skipf("%s: unsupported in this environment", name)
 	}
-}
-
-func BenchmarkCartaOneResult(b *testing.B) {
-	type order struct {
-		ID           int32      `db:"orders.id"`
-		CustomerName string     `db:"orders.customer_name"`
-		CreatedAt    *time.Time `db:"orders.created_at"`
-		Itens        []struct {
-			OrderItemID int32   `db:"order_items.id"`
-			OrderID     *int32  `db:"order_items.order_id"`
-			ProductName string  `db:"order_items.product_name"`
-			Price       float64 `db:"order_items.price"`
-			Quantity    *int32  `db:"order_items.quantity"`
-		}
+	if err != nil {
+		tb.Fatalf("%s: failed to initialize: %v", name, err)
 	}
+	return f
+}
 
-	const query = `
-	SELECT orders.id AS "orders.id",
-		orders.customer_name AS "orders.customer_name",
-		orders.created_at AS "orders.created_at",
-		order_items.id AS "order_items.id",
-		order_items.order_id AS "order_items.order_id",
-		order_items.product_name AS "order_items.product_name",
-		order_items.price AS "order_items.price",
-		order_items.quantity AS "order_items.quantity"
-	FROM public.orders
-		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-	WHERE orders.id = 1
-	ORDER BY orders.id ASC;
-	`
-
-	for range b.N {
-		rows, err := db.QueryContext(b.Context(), query)
-		if err != nil {
-			b.Fatalf("query failed: %v", err)
-		}
-
-		order := order{}
-		err = carta.Map(rows, &order)
-		if err != nil {
-			b.Fatalf("mapping failed: %v", err)
-		}
+// scenario is one query shape every contender is benchmarked against.
+// drain runs the same query through a floor.
+type scenario struct {
+	name  string
+	run   func(b *testing.B, c contender.Contender)
+	drain func(b *testing.B, f contender.Floor)
+}
 
-		if len(order.Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(order.Itens))
-		}
-	}
+var scenarios = []scenario{
+	{name: "All", run: benchmarkFetchAll, drain: benchmarkDrainAll},
+	{name: "OneResult", run: benchmarkFetchOne, drain: benchmarkDrainOne},
 }
 
-func BenchmarkGorm(b *testing.B) {
-	gormDB, err := gorm.Open(postgres.New(postgres.Config{
-		DSN:                  "host=localhost port=5432 user=postgres password=admin dbname=order sslmode=disable",
-		PreferSimpleProtocol: true,
-	}), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	if err != nil {
-		b.Fatalf("failed to initialize GORM: %v", err)
+func BenchmarkContenders(b *testing.B) {
+	for _, s := range scenarios {
+		b.Run(s.name, func(b *testing.B) {
+			res := results{}
+			for _, name := range contender.FloorNames() {
+				b.Run(name, func(b *testing.B) {
+					f := getFloor(b, name)
+					wire := wireSnapshot()
+					mallocs := memSnapshot()
+					b.ResetTimer()
+					s.drain(b, f)
+					b.StopTimer()
+					res.record(b, name, mallocs)
+					reportWire(b, wire)
+				})
+			}
+			for _, name := range contender.Names() {
+				b.Run(name, func(b *testing.B) {
+					c := getContender(b, name)
+					wire := wireSnapshot()
+					mallocs := memSnapshot()
+					b.ResetTimer()
+					s.run(b, c)
+					b.StopTimer()
+					res.record(b, name, mallocs)
+					reportWire(b, wire)
+				})
+			}
+			res.log(b, append(contender.FloorNames(), contender.Names()...))
+		})
 	}
+}
 
-	b.ResetTimer()
+func benchmarkFetchAll(b *testing.B, c contender.Contender) {
+	shape := datasetShape(b)
+	skipBounded(b, c, shape)
 
 	for range b.N {
-		var orders []OrderWithItems
-		err := gormDB.Preload("Itens").Find(&orders).Error
+		orders, err := c.FetchAll(b.Context())
 		if err != nil {
 			b.Fatalf("query failed: %v", err)
 		}
 
-		if len(orders) != 50000 {
-			b.Fatalf("expected 50000 results, got %d", len(orders))
-		}
-
-		if len(orders[0].Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(orders[0].Itens))
-		}
+		checkOrders(b, orders, shape)
 	}
 }
 
-func BenchmarkGormOneResult(b *testing.B) {
-	gormDB, err := gorm.Open(postgres.New(postgres.Config{
-		DSN:                  "host=localhost port=5432 user=postgres password=admin dbname=order sslmode=disable",
-		PreferSimpleProtocol: true,
-	}), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	if err != nil {
-		b.Fatalf("failed to initialize GORM: %v", err)
-	}
+func benchmarkFetchOne(b *testing.B, c contender.Contender) {
+	shape := datasetShape(b)
 
-	b.ResetTimer()
-
-	for i := 0; i < b.N; i++ {
-		var order OrderWithItems
-		err := gormDB.Preload("Itens").First(&order, "id = ?", 1).Error
+	for range b.N {
+		order, err := c.FetchOne(b.Context(), shape.SampleOrderID)
 		if err != nil {
 			b.Fatalf("query failed: %v", err)
 		}
 
-		if len(order.Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(order.Itens))
+		if len(order.Itens) != shape.SampleOrderItems {
+			b.Fatalf("expected %d itens, got %d", shape.SampleOrderItems, len(order.Itens))
 		}
 	}
 }
 
-func BenchmarkPq(b *testing.B) {
-	const query = `
-		SELECT orders.id AS "orders.id",
-			orders.customer_name AS "orders.customer_name",
-			orders.created_at AS "orders.created_at",
-			order_items.id AS "order_items.id",
-			order_items.order_id AS "order_items.order_id",
-			order_items.product_name AS "order_items.product_name",
-			order_items.price AS "order_items.price",
-			order_items.quantity AS "order_items.quantity"
-		FROM public.orders
-			INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-		ORDER BY orders.id ASC;
-	`
-
-	type OrderWithItems struct {
-		model.Orders
-		Itens []model.OrderItems
-	}
+func benchmarkDrainAll(b *testing.B, f contender.Floor) {
+	shape := datasetShape(b)
 
 	for range b.N {
-		rows, err := db.Query(query)
+		rows, err := f.DrainAll(b.Context())
 		if err != nil {
 			b.Fatalf("query failed: %v", err)
 		}
-		defer rows.Close()
-
-		var orders []OrderWithItems
-		orderIdx := make(map[int32]int)
-		for rows.Next() {
-			var row struct {
-				ID           int32
-				CustomerName string
-				CreatedAt    *time.Time
-				OrderItemID  int32
-				OrderID      *int32
-				ProductName  string
-				Price        float64
-				Quantity     *int32
-			}
-			if err := rows.Scan(&row.ID, &row.CustomerName, &row.CreatedAt, &row.OrderItemID, &row.OrderID, &row.ProductName, &row.Price, &row.Quantity); err != nil {
-				b.Fatalf("row scan failed: %v", err)
-			}
-
-			idx, ok := orderIdx[row.ID]
-			if !ok {
-				orders = append(orders, OrderWithItems{
-					Orders: model.Orders{
-						ID:           row.ID,
-						CustomerName: row.CustomerName,
-						CreatedAt:    row.CreatedAt,
-					},
-				})
-				idx = len(orders) - 1
-				orderIdx[row.ID] = idx
-			}
-			orders[idx].Itens = append(orders[idx].Itens, model.OrderItems{
-				ID:          row.OrderItemID,
-				OrderID:     row.OrderID,
-				ProductName: row.ProductName,
-				Price:       row.Price,
-				Quantity:    row.Quantity,
-			})
-		}
 
-		if len(orders) != 50000 {
-			b.Fatalf("expected 50000 results, got %d", len(orders))
-		}
-
-		if len(orders[0].Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(orders[0].Itens))
+		if rows != shape.OrderItems {
+			b.Fatalf("expected %d rows, got %d", shape.OrderItems, rows)
 		}
 	}
 }
 
-func BenchmarkPqOneResult(b *testing.B) {
-	const query = `
-		SELECT orders.id AS "orders.id",
-			orders.customer_name AS "orders.customer_name",
-			orders.created_at AS "orders.created_at",
-			order_items.id AS "order_items.id",
-			order_items.order_id AS "order_items.order_id",
-			order_items.product_name AS "order_items.product_name",
-			order_items.price AS "order_items.price",
-			order_items.quantity AS "order_items.quantity"
-		FROM public.orders
-			INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-		WHERE orders.id = 1
-		ORDER BY orders.id ASC;
-	`
-
-	type OrderWithItems struct {
-		model.Orders
-		Itens []model.OrderItems
-	}
+func benchmarkDrainOne(b *testing.B, f contender.Floor) {
+	shape := datasetShape(b)
 
 	for range b.N {
-		rows, err := db.Query(query)
+		rows, err := f.DrainOne(b.Context(), shape.SampleOrderID)
 		if err != nil {
 			b.Fatalf("query failed: %v", err)
 		}
-		defer rows.Close()
-
-		var order OrderWithItems
-		for rows.Next() {
-			var row struct {
-				ID           int32
-				CustomerName string
-				CreatedAt    *time.Time
-				OrderItemID  int32
-				OrderID      *int32
-				ProductName  string
-				Price        float64
-				Quantity     *int32
-			}
-			if err := rows.Scan(&row.ID, &row.CustomerName, &row.CreatedAt, &row.OrderItemID, &row.OrderID, &row.ProductName, &row.Price, &row.Quantity); err != nil {
-				b.Fatalf("row scan failed: %v", err)
-			}
-
-			order.Itens = append(order.Itens, model.OrderItems{
-				ID:          row.OrderItemID,
-				OrderID:     row.OrderID,
-				ProductName: row.ProductName,
-				Price:       row.Price,
-				Quantity:    row.Quantity,
-			})
-		}
 
-		if len(order.Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(order.Itens))
+		if rows != shape.SampleOrderItems {
+			b.Fatalf("expected %d rows, got %d", shape.SampleOrderItems, rows)
 		}
 	}
 }
 
-func BenchmarkPqJsonAgg(b *testing.B) {
-	const query = `
-		SELECT orders.id AS "orders.id",
-			   orders.customer_name AS "orders.customer_name",
-			   orders.created_at AS "orders.created_at",
-			   json_agg(json_build_object(
-				   'id', order_items.id,
-				   'order_id', order_items.order_id,
-				   'product_name', order_items.product_name,
-				   'price', order_items.price,
-				   'quantity', order_items.quantity
-			   )) AS "order_items"
-		FROM public.orders
-		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-		GROUP BY orders.id, orders.customer_name, orders.created_at
-		ORDER BY orders.id ASC;
-	`
-
-	type OrderItem struct {
-		OrderItemID int32   `json:"id"`
-		OrderID     *int32  `json:"order_id"`
-		ProductName string  `json:"product_name"`
-		Price       float64 `json:"price"`
-		Quantity    *int32  `json:"quantity"`
-	}
+// skipBounded skips a full fetch by a contender.Bounded contender when the
+// dataset has more orders than it is benchmarked at.
+func skipBounded(tb testing.TB, c contender.Contender, shape dataset.Shape) {
+	tb.Helper()
 
-	type OrderWithItems struct {
-		ID           int32       `db:"orders.id"`
-		CustomerName string      `db:"orders.customer_name"`
-		CreatedAt    *time.Time  `db:"orders.created_at"`
-		Itens        []OrderItem `json:"order_items"`
+	bounded, ok := c.(contender.Bounded)
+	if !ok || bounded.MaxOrders() == 0 || shape.Orders <= bounded.MaxOrders() {
+		return
 	}
+	tb.Skipf("the dataset has %d orders, more than the %d this contender fetches in full", shape.Orders, bounded.MaxOrders())
+}
 
-	for range b.N {
-		orders := make([]OrderWithItems, 0, 50000)
-		rows, err := db.QueryContext(b.Context(), query)
-		if err != nil {
-			b.Fatalf("query failed: %v", err)
-		}
-		defer rows.Close()
-
-		for rows.Next() {
-			var (
-				id             int32
-				customerName   string
-				createdAt      *time.Time
-				orderItemsJSON []byte
-			)
-			if err := rows.Scan(&id, &customerName, &createdAt, &orderItemsJSON); err != nil {
-				b.Fatalf("row scan failed: %v", err)
-			}
-			var itens []OrderItem
-			if err := json.Unmarshal(orderItemsJSON, &itens); err != nil {
-				b.Fatalf("failed to unmarshal order items: %v", err)
-			}
-			orders = append(orders, OrderWithItems{
-				ID:           id,
-				CustomerName: customerName,
-				CreatedAt:    createdAt,
-				Itens:        itens,
-			})
-		}
-
-		if len(orders) != 50000 {
-			b.Fatalf("expected 50000 results, got %d", len(orders))
-		}
-		if len(orders[0].Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(orders[0].Itens))
-		}
+// wireSnapshot returns the traffic the proxy has seen so far, or nothing
+// without -wire-stats.
+func wireSnapshot() pgproxy.Stats {
+	if wireProxy == nil || !*wireStats {
+		return pgproxy.Stats{}
 	}
+	return wireProxy.Stats()
 }
 
-func BenchmarkPqJsonAggOneResult(b *testing.B) {
-	const query = `
-		SELECT orders.id AS "orders.id",
-			   orders.customer_name AS "orders.customer_name",
-			   orders.created_at AS "orders.created_at",
-			   json_agg(json_build_object(
-				   'id', order_items.id,
-				   'order_id', order_items.order_id,
-				   'product_name', order_items.product_name,
-				   'price', order_items.price,
-				   'quantity', order_items.quantity
-			   )) AS "order_items"
-		FROM public.orders
-		INNER JOIN public.order_items ON (orders.id = order_items.order_id)
-		WHERE orders.id = 1
-		GROUP BY orders.id, orders.customer_name, orders.created_at
-		ORDER BY orders.id ASC;
-	`
-
-	type OrderItem struct {
-		OrderItemID int32   `json:"id"`
-		OrderID     *int32  `json:"order_id"`
-		ProductName string  `json:"product_name"`
-		Price       float64 `json:"price"`
-		Quantity    *int32  `json:"quantity"`
+// reportWire reports the proxy traffic since before per op. Sent and
+// received are from the client's side.
+func reportWire(b *testing.B, before pgproxy.Stats) {
+	if wireProxy == nil || !*wireStats {
+		return
 	}
 
-	type OrderWithItems struct {
-		ID           int32       `db:"orders.id"`
-		CustomerName string      `db:"orders.customer_name"`
-		CreatedAt    *time.Time  `db:"orders.created_at"`
-		Itens        []OrderItem `json:"order_items"`
-	}
+	d := wireProxy.Stats().Sub(before)
+	ops := float64(b.N)
+	b.ReportMetric(float64(d.Queries)/ops, "queries/op")
+	b.ReportMetric(float64(d.RoundTrips)/ops, "roundtrips/op")
+	b.ReportMetric(float64(d.ClientMessages+d.ServerMessages)/ops, "msgs/op")
+	b.ReportMetric(float64(d.ClientBytes)/ops, "wire-sent-B/op")
+	b.ReportMetric(float64(d.ServerBytes)/ops, "wire-recv-B/op")
+	b.ReportMetric(float64(d.ClientBytes+d.ServerBytes)/ops, "wire-bytes/op")
+}
 
-	for range b.N {
-		var order OrderWithItems
-		rows, err := db.QueryContext(b.Context(), query)
-		if err != nil {
-			b.Fatalf("query failed: %v", err)
-		}
+// checkOrders fails b unless orders hold exactly the orders with items and
+// the items of the dataset. Orders without items are ignored, since only
+// some contenders return them.
+func checkOrders(b *testing.B, orders []contender.Order, shape dataset.Shape) {
+	b.Helper()
 
-		if rows.Next() {
-			var orderItemsJSON []byte
-			if err := rows.Scan(&order.ID, &order.CustomerName, &order.CreatedAt, &orderItemsJSON); err != nil {
-				b.Fatalf("row scan failed: %v", err)
-			}
-			if err := json.Unmarshal(orderItemsJSON, &order.Itens); err != nil {
-				b.Fatalf("failed to unmarshal order items: %v", err)
-			}
+	withItems, items := 0, 0
+	for i := range orders {
+		if n := len(orders[i].Itens); n > 0 {
+			withItems++
+			items += n
 		}
+	}
 
-		if len(order.Itens) != 5 {
-			b.Fatalf("expected 5 itens, got %d", len(order.Itens))
-		}
+	if withItems != shape.OrdersWithItems {
+		b.Fatalf("expected %d results, got %d", shape.OrdersWithItems, withItems)
+	}
+
+	if items != shape.OrderItems {
+		b.Fatalf("expected %d itens, got %d", shape.OrderItems, items)
 	}
 }
@@ -0,0 +1,87 @@
+// Package metrics holds the statistics the benchmarks report on top of
+// ns/op, B/op and allocs/op.
+package metrics
+
+import "math"
+
+// FitRelative returns the coefficients of y = c[0] + c[1]*x[0] + c[2]*x[1]
+// + ... through the given points, xs[i] holding the variables of point i.
+// It minimizes the relative residuals (y - fit) / y rather than the
+// absolute ones, so points spanning several orders of magnitude weigh
+// alike and the intercept is not left to the largest of them. ys must be
+// positive. ok is false when the variables and the constant are collinear
+// or there are fewer points than coefficients.
+func FitRelative(xs [][]float64, ys []float64) (c []float64, ok bool) {
+	if len(xs) == 0 {
+		return nil, false
+	}
+	k := len(xs[0]) + 1
+	if len(xs) < k {
+		return nil, false
+	}
+
+	// Scale every column to at most 1 so the normal equations stay well
+	// conditioned when the variables differ by orders of magnitude.
+	scale := make([]float64, k)
+	scale[0] = 1
+	for _, x := range xs {
+		for j, v := range x {
+			scale[j+1] = max(scale[j+1], math.Abs(v))
+		}
+	}
+	for j := range scale {
+		if scale[j] == 0 {
+			return nil, false
+		}
+	}
+
+	// a is the augmented matrix of the weighted normal equations, each
+	// point weighted by 1/y².
+	a := make([][]float64, k)
+	for j := range a {
+		a[j] = make([]float64, k+1)
+	}
+	row := make([]float64, k)
+	for i, x := range xs {
+		row[0] = 1
+		for j, v := range x {
+			row[j+1] = v / scale[j+1]
+		}
+		w := 1 / (ys[i] * ys[i])
+		for r := range k {
+			for s := range k {
+				a[r][s] += w * row[r] * row[s]
+			}
+			a[r][k] += w * row[r] * ys[i]
+		}
+	}
+
+	// The normal equations are symmetric positive semidefinite, so they
+	// are eliminated without pivoting. A pivot that has shrunk to almost
+	// nothing next to its starting value means its column is a
+	// combination of the ones before it.
+	diag := make([]float64, k)
+	for j := range k {
+		diag[j] = a[j][j]
+	}
+	for col := range k {
+		if a[col][col] <= 1e-9*diag[col] {
+			return nil, false
+		}
+		for r := range k {
+			if r == col {
+				continue
+			}
+			f := a[r][col] / a[col][col]
+			for s := col; s <= k; s++ {
+				a[r][s] -= f * a[col][s]
+			}
+		}
+	}
+
+	c = make([]float64, k)
+	for j := range k {
+		c[j] = a[j][k] / a[j][j] / scale[j]
+	}
+	return c, true
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitRelative(t *testing.T) {
+	var (
+		xs [][]float64
+		ys []float64
+	)
+	for _, orders := range []float64{1, 100, 10000, 1000000} {
+		for _, items := range []float64{1, 5, 50} {
+			rows := orders * items
+			xs = append(xs, []float64{orders, rows})
+			ys = append(ys, 50000+300*orders+120*rows)
+		}
+	}
+
+	c, ok := FitRelative(xs, ys)
+	if !ok {
+		t.Fatal("expected a fit")
+	}
+	for i, want := range []float64{50000, 300, 120} {
+		if math.Abs(c[i]-want) > 1e-6*want {
+			t.Errorf("coefficient %d: expected %g, got %g", i, want, c[i])
+		}
+	}
+
+	// With a single items value rows is a multiple of orders.
+	collinear := [][]float64{{1, 5}, {100, 500}, {10000, 50000}}
+	if _, ok := FitRelative(collinear, []float64{1, 2, 3}); ok {
+		t.Error("expected no fit for collinear variables")
+	}
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// subBits sets the precision of Histogram: every power of two is split in
+// 2^subBits buckets, so recorded values are off by less than 1%.
+const (
+	subBits  = 7
+	subCount = 1 << subBits
+)
+
+// Histogram is an HDR-style log-linear histogram of durations. It is not
+// safe for concurrent use; record into one histogram per goroutine and
+// Merge them.
+type Histogram struct {
+	counts []uint64
+	total  uint64
+	max    time.Duration
+}
+
+// NewHistogram returns an empty histogram covering every positive duration.
+func NewHistogram() *Histogram {
+	return &Histogram{counts: make([]uint64, bucketIndex(math.MaxUint64)+1)}
+}
+
+// Record adds one observation.
+func (h *Histogram) Record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	h.counts[bucketIndex(uint64(d))]++
+	h.total++
+	h.max = max(h.max, d)
+}
+
+// Merge adds every observation of other to h.
+func (h *Histogram) Merge(other *Histogram) {
+	for i, n := range other.counts {
+		h.counts[i] += n
+	}
+	h.total += other.total
+	h.max = max(h.max, other.max)
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() uint64 {
+	return h.total
+}
+
+// Quantile returns the smallest duration that at least q (0 to 1) of the
+// observations do not exceed, within the histogram's precision.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	rank := uint64(math.Ceil(q * float64(h.total)))
+	rank = max(rank, 1)
+
+	var seen uint64
+	for i, n := range h.counts {
+		seen += n
+		if seen >= rank {
+			return min(time.Duration(bucketValue(i+1)-1), h.max)
+		}
+	}
+	return h.max
+}
+
+// bucketIndex maps v to its bucket. Values below 2*subCount get a bucket
+// each; above that every power of two is split in subCount buckets.
+func bucketIndex(v uint64) int {
+	if v < 2*subCount {
+		return int(v)
+	}
+	shift := bits.Len64(v) - subBits - 1
+	return (shift+1)*subCount + int(v>>shift) - subCount
+}
+
+// bucketValue returns the lowest value of bucket i.
+func bucketValue(i int) uint64 {
+	if i < 2*subCount {
+		return uint64(i)
+	}
+	shift := i/subCount - 1
+	return uint64(i%subCount+subCount) << shift
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	a, b := NewHistogram(), NewHistogram()
+	for i := 1; i <= 1000; i++ {
+		h := a
+		if i%2 == 0 {
+			h = b
+		}
+		h.Record(time.Duration(i) * time.Microsecond)
+	}
+	a.Merge(b)
+
+	if a.Count() != 1000 {
+		t.Fatalf("expected 1000 observations, got %d", a.Count())
+	}
+
+	for _, tt := range []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0.5, 500 * time.Microsecond},
+		{0.99, 990 * time.Microsecond},
+		{1, 1000 * time.Microsecond},
+	} {
+		got := a.Quantile(tt.q)
+		if diff := math.Abs(float64(got-tt.want)) / float64(tt.want); diff > 0.01 {
+			t.Errorf("p%g: expected %s within 1%%, got %s", tt.q*100, tt.want, got)
+		}
+	}
+}
+
+func TestBucketIndexRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 255, 256, 344, 1 << 20, 123456789, math.MaxUint64} {
+		i := bucketIndex(v)
+		if lo := bucketValue(i); lo > v {
+			t.Errorf("value %d: bucket %d starts at %d", v, i, lo)
+		}
+		if i+1 < len(NewHistogram().counts) && bucketValue(i+1) <= v {
+			t.Errorf("value %d: bucket %d ends at %d", v, i, bucketValue(i+1))
+		}
+	}
+}
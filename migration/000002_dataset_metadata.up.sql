@@ -0,0 +1,30 @@
+-- Shape of the seeded dataset, rewritten by `go run ./cmd/seed`. The
+-- benchmarks read their expected counts from here.
+CREATE TABLE dataset_metadata (
+    orders INTEGER NOT NULL,
+    order_items INTEGER NOT NULL,
+    orders_with_items INTEGER NOT NULL,
+    sample_order_id INTEGER NOT NULL,
+    sample_order_items INTEGER NOT NULL,
+    distribution VARCHAR(255) NOT NULL,
+    seed BIGINT NOT NULL,
+    customer_name_length INTEGER NOT NULL,
+    product_name_length INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Describe whatever seed_database left behind.
+INSERT INTO dataset_metadata (
+    orders, order_items, orders_with_items, sample_order_id, sample_order_items,
+    distribution, seed, customer_name_length, product_name_length
+)
+SELECT
+    (SELECT COUNT(*) FROM orders),
+    (SELECT COUNT(*) FROM order_items),
+    (SELECT COUNT(DISTINCT order_id) FROM order_items),
+    COALESCE((SELECT MIN(order_id) FROM order_items), 0),
+    (SELECT COUNT(*) FROM order_items WHERE order_id = (SELECT MIN(order_id) FROM order_items)),
+    'fixed:5',
+    0,
+    0,
+    0;
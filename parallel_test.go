@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+	"github.com/lucasHSantiago/go-select-benchmark/metrics"
+)
+
+var parallelism = flag.String("parallelism", "1,4,16", "goroutines per GOMAXPROCS swept by BenchmarkParallel; combine with -cpu to sweep GOMAXPROCS")
+
+// BenchmarkParallel runs the OneResult scenario from parallelism *
+// GOMAXPROCS goroutines at once and reports latency percentiles next to
+// ns/op, which under b.RunParallel is wall time divided by operations.
+func BenchmarkParallel(b *testing.B) {
+	for _, p := range parseInts(b, *parallelism) {
+		b.Run(fmt.Sprintf("OneResult/p=%d", p), func(b *testing.B) {
+			for _, name := range contender.Names() {
+				b.Run(name, func(b *testing.B) {
+					c := getContender(b, name)
+					if _, ok := c.(contender.Serial); ok {
+						b.Skipf("%s is not safe for concurrent use", name)
+					}
+					b.SetParallelism(p)
+					wire := wireSnapshot()
+					b.ResetTimer()
+					benchmarkFetchOneParallel(b, c)
+					reportWire(b, wire)
+				})
+			}
+		})
+	}
+}
+
+func benchmarkFetchOneParallel(b *testing.B, c contender.Contender) {
+	shape := datasetShape(b)
+
+	var mu sync.Mutex
+	latencies := metrics.NewHistogram()
+
+	b.RunParallel(func(pb *testing.PB) {
+		local := metrics.NewHistogram()
+		defer func() {
+			mu.Lock()
+			latencies.Merge(local)
+			mu.Unlock()
+		}()
+
+		for pb.Next() {
+			start := time.Now()
+			order, err := c.FetchOne(b.Context(), shape.SampleOrderID)
+			local.Record(time.Since(start))
+
+			if err != nil {
+				b.Errorf("query failed: %v", err)
+				return
+			}
+			if len(order.Itens) != shape.SampleOrderItems {
+				b.Errorf("expected %d itens, got %d", shape.SampleOrderItems, len(order.Itens))
+				return
+			}
+		}
+	})
+
+	b.StopTimer()
+	for _, q := range []struct {
+		quantile float64
+		unit     string
+	}{
+		{0.5, "p50-ns"},
+		{0.95, "p95-ns"},
+		{0.99, "p99-ns"},
+		{0.999, "p99.9-ns"},
+	} {
+		b.ReportMetric(float64(latencies.Quantile(q.quantile).Nanoseconds()), q.unit)
+	}
+}
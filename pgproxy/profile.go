@@ -0,0 +1,173 @@
+package pgproxy
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Profile describes the network a Proxy simulates between clients and
+// the server. The zero Profile forwards everything immediately.
+type Profile struct {
+	// RTT is the round-trip time added to every exchange. Each direction
+	// is delayed by half of it.
+	RTT time.Duration
+	// Jitter is the most extra delay, drawn uniformly, that each chunk
+	// gets on top of RTT/2. Chunks are never reordered.
+	Jitter time.Duration
+	// Bandwidth caps every connection, in each direction, to this many
+	// bytes per second; 0 leaves it unlimited.
+	Bandwidth int64
+}
+
+// Profiles are the named profiles ParseProfile accepts.
+var Profiles = map[string]Profile{
+	"local":  {},
+	"lan":    {RTT: 500 * time.Microsecond, Jitter: 50 * time.Microsecond},
+	"az":     {RTT: 2 * time.Millisecond, Jitter: 200 * time.Microsecond, Bandwidth: 1000 * mbit},
+	"region": {RTT: 20 * time.Millisecond, Jitter: 2 * time.Millisecond, Bandwidth: 100 * mbit},
+}
+
+// mbit is one megabit per second in bytes per second.
+const mbit = 1_000_000 / 8
+
+// ParseProfile parses the name of one of Profiles or
+// "RTT[:JITTER[:MBIT]]", e.g. "2ms:200us:1000" for a 2ms round trip with
+// up to 200µs of jitter on a 1000 Mbit/s link.
+func ParseProfile(s string) (Profile, error) {
+	if p, ok := Profiles[s]; ok {
+		return p, nil
+	}
+
+	fields := strings.Split(s, ":")
+	if len(fields) > 3 {
+		return Profile{}, fmt.Errorf("pgproxy: invalid profile %q, want a name or RTT[:JITTER[:MBIT]]", s)
+	}
+
+	var p Profile
+	var err error
+	if p.RTT, err = time.ParseDuration(fields[0]); err != nil || p.RTT < 0 {
+		return Profile{}, fmt.Errorf("pgproxy: invalid round-trip time in %q", s)
+	}
+	if len(fields) > 1 {
+		if p.Jitter, err = time.ParseDuration(fields[1]); err != nil || p.Jitter < 0 {
+			return Profile{}, fmt.Errorf("pgproxy: invalid jitter in %q", s)
+		}
+	}
+	if len(fields) > 2 {
+		mbits, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || mbits < 0 {
+			return Profile{}, fmt.Errorf("pgproxy: invalid bandwidth in %q", s)
+		}
+		p.Bandwidth = int64(mbits * mbit)
+	}
+	return p, nil
+}
+
+// String returns the profile in the form ParseProfile accepts.
+func (p Profile) String() string {
+	return fmt.Sprintf("%s:%s:%g", p.RTT, p.Jitter, float64(p.Bandwidth)/mbit)
+}
+
+// delay returns how long a chunk of n bytes takes to arrive.
+func (p Profile) delay() time.Duration {
+	d := p.RTT / 2
+	if p.Jitter > 0 {
+		d += rand.N(p.Jitter)
+	}
+	return d
+}
+
+// transmit returns how long n bytes occupy the link.
+func (p Profile) transmit(n int) time.Duration {
+	if p.Bandwidth == 0 {
+		return 0
+	}
+	return time.Duration(float64(n) / float64(p.Bandwidth) * float64(time.Second))
+}
+
+// link delivers chunks to dst in order, each no earlier than its due
+// time. It owns a goroutine until close.
+type link struct {
+	dst     io.Writer
+	chunks  chan chunk
+	pending atomic.Int64
+	failed  atomic.Bool
+	done    sync.WaitGroup
+
+	// busy is when the last queued chunk finishes transmitting and last
+	// is when it arrives; later chunks never arrive before either.
+	busy time.Time
+	last time.Time
+}
+
+type chunk struct {
+	data []byte
+	due  time.Time
+}
+
+func newLink(dst io.Writer) *link {
+	l := &link{dst: dst, chunks: make(chan chunk, 256)}
+	l.done.Add(1)
+	go func() {
+		defer l.done.Done()
+		for c := range l.chunks {
+			if !l.failed.Load() {
+				time.Sleep(time.Until(c.due))
+				if _, err := l.dst.Write(c.data); err != nil {
+					l.failed.Store(true)
+				}
+			}
+			l.pending.Add(-1)
+		}
+	}()
+	return l
+}
+
+// send writes b to dst as shaped by p, returning false once a write has
+// failed. b may be reused as soon as send returns.
+func (l *link) send(b []byte, p *Profile) bool {
+	if l.failed.Load() {
+		return false
+	}
+
+	// Nothing queued and nothing to simulate: write straight through.
+	if p == nil && l.pending.Load() == 0 {
+		if _, err := l.dst.Write(b); err != nil {
+			l.failed.Store(true)
+			return false
+		}
+		return true
+	}
+
+	now := time.Now()
+	due := now
+	if p != nil {
+		l.busy = later(l.busy, now).Add(p.transmit(len(b)))
+		due = l.busy.Add(p.delay())
+	}
+	due = later(due, l.last)
+	l.last = due
+
+	l.pending.Add(1)
+	l.chunks <- chunk{data: append([]byte(nil), b...), due: due}
+	return true
+}
+
+// close waits for every queued chunk to be delivered or dropped.
+func (l *link) close() {
+	close(l.chunks)
+	l.done.Wait()
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,301 @@
+// Package pgproxy implements a local TCP proxy that sits between Postgres
+// clients and a server, counts the protocol traffic passing through and
+// can delay it to simulate a slower network.
+//
+// It understands just enough of the frontend/backend protocol to split
+// the byte stream into messages, so it can tell how many statements and
+// round trips a library needs for a query and how many bytes it moves.
+package pgproxy
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// sslRequestCode is the protocol version an SSLRequest carries in place of
+// a real one.
+const sslRequestCode = 80877103
+
+// Stats counts the traffic of every connection a Proxy has handled.
+type Stats struct {
+	// Queries counts simple Query and extended Execute messages.
+	Queries int64
+	// RoundTrips counts the times a client sent something and then
+	// received a response.
+	RoundTrips int64
+	// ClientMessages and ClientBytes count what clients sent.
+	ClientMessages int64
+	ClientBytes    int64
+	// ServerMessages and ServerBytes count what the server sent.
+	ServerMessages int64
+	ServerBytes    int64
+}
+
+// Sub returns the traffic counted since prev was taken.
+func (s Stats) Sub(prev Stats) Stats {
+	return Stats{
+		Queries:        s.Queries - prev.Queries,
+		RoundTrips:     s.RoundTrips - prev.RoundTrips,
+		ClientMessages: s.ClientMessages - prev.ClientMessages,
+		ClientBytes:    s.ClientBytes - prev.ClientBytes,
+		ServerMessages: s.ServerMessages - prev.ServerMessages,
+		ServerBytes:    s.ServerBytes - prev.ServerBytes,
+	}
+}
+
+type counters struct {
+	queries        atomic.Int64
+	roundTrips     atomic.Int64
+	clientMessages atomic.Int64
+	clientBytes    atomic.Int64
+	serverMessages atomic.Int64
+	serverBytes    atomic.Int64
+}
+
+// Proxy forwards every connection it accepts to an upstream Postgres.
+type Proxy struct {
+	upstream string
+	stats    counters
+	profile  atomic.Pointer[Profile]
+
+	ln    net.Listener
+	wg    sync.WaitGroup
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+// New returns a proxy to the Postgres listening on upstream, a host:port
+// address.
+func New(upstream string) *Proxy {
+	return &Proxy{upstream: upstream, conns: map[net.Conn]struct{}{}}
+}
+
+// Listen starts accepting connections on addr, e.g. "127.0.0.1:0".
+func (p *Proxy) Listen(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	p.ln = ln
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.serve()
+	}()
+	return nil
+}
+
+// Addr returns the address the proxy listens on.
+func (p *Proxy) Addr() net.Addr {
+	return p.ln.Addr()
+}
+
+// SetProfile makes the proxy simulate p from now on, including on open
+// connections.
+func (p *Proxy) SetProfile(profile Profile) {
+	if profile == (Profile{}) {
+		p.profile.Store(nil)
+		return
+	}
+	p.profile.Store(&profile)
+}
+
+// Stats returns the traffic counted so far.
+func (p *Proxy) Stats() Stats {
+	return Stats{
+		Queries:        p.stats.queries.Load(),
+		RoundTrips:     p.stats.roundTrips.Load(),
+		ClientMessages: p.stats.clientMessages.Load(),
+		ClientBytes:    p.stats.clientBytes.Load(),
+		ServerMessages: p.stats.serverMessages.Load(),
+		ServerBytes:    p.stats.serverBytes.Load(),
+	}
+}
+
+// Close stops the proxy and closes every open connection.
+func (p *Proxy) Close() error {
+	err := p.ln.Close()
+
+	p.mu.Lock()
+	for conn := range p.conns {
+		conn.Close()
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	return err
+}
+
+func (p *Proxy) serve() {
+	for {
+		client, err := p.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.handle(client)
+		}()
+	}
+}
+
+func (p *Proxy) track(conns ...net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range conns {
+		p.conns[conn] = struct{}{}
+	}
+}
+
+func (p *Proxy) untrack(conns ...net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range conns {
+		delete(p.conns, conn)
+		conn.Close()
+	}
+}
+
+func (p *Proxy) handle(client net.Conn) {
+	p.track(client)
+	defer p.untrack(client)
+
+	br := bufio.NewReader(client)
+	if err := refuseSSL(br, client); err != nil {
+		return
+	}
+
+	server, err := net.Dial("tcp", p.upstream)
+	if err != nil {
+		return
+	}
+	p.track(server)
+	defer p.untrack(server)
+
+	// awaiting is set before client bytes go upstream and cleared by the
+	// first server bytes that follow, which completes a round trip.
+	var awaiting atomic.Bool
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		up := &framer{startup: true, onMessage: func(typ byte) {
+			p.stats.clientMessages.Add(1)
+			if typ == 'Q' || typ == 'E' {
+				p.stats.queries.Add(1)
+			}
+		}}
+		p.pump(server, br, func(b []byte) {
+			awaiting.Store(true)
+			p.stats.clientBytes.Add(int64(len(b)))
+			up.feed(b)
+		})
+		server.Close()
+	}()
+
+	down := &framer{onMessage: func(byte) {
+		p.stats.serverMessages.Add(1)
+	}}
+	p.pump(client, server, func(b []byte) {
+		if awaiting.Swap(false) {
+			p.stats.roundTrips.Add(1)
+		}
+		p.stats.serverBytes.Add(int64(len(b)))
+		down.feed(b)
+	})
+	client.Close()
+	<-done
+}
+
+// refuseSSL answers an SSLRequest with 'N', as a server without TLS
+// would, and leaves any other first message unread. Clients then send
+// their StartupMessage in the clear, which keeps the stream countable.
+func refuseSSL(br *bufio.Reader, client net.Conn) error {
+	hdr, err := br.Peek(8)
+	if err != nil {
+		return err
+	}
+	if binary.BigEndian.Uint32(hdr[:4]) != 8 || binary.BigEndian.Uint32(hdr[4:]) != sslRequestCode {
+		return nil
+	}
+
+	if _, err := br.Discard(8); err != nil {
+		return err
+	}
+	_, err = client.Write([]byte{'N'})
+	return err
+}
+
+// pump copies src to dst until either fails, passing every chunk to
+// observe as it is read and delaying it as the current profile says.
+func (p *Proxy) pump(dst io.Writer, src io.Reader, observe func([]byte)) {
+	l := newLink(dst)
+	defer l.close()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			observe(buf[:n])
+			if !l.send(buf[:n], p.profile.Load()) {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// framer splits a protocol byte stream into messages as it arrives in
+// arbitrary chunks. Every message is a type byte followed by an int32
+// length that counts itself, except the startup message, which has no
+// type byte.
+type framer struct {
+	startup   bool
+	onMessage func(typ byte)
+
+	header    [5]byte
+	filled    int
+	remaining int
+}
+
+func (f *framer) feed(b []byte) {
+	for len(b) > 0 {
+		if f.remaining > 0 {
+			n := min(f.remaining, len(b))
+			f.remaining -= n
+			b = b[n:]
+			continue
+		}
+
+		size := len(f.header)
+		if f.startup {
+			size = 4
+		}
+
+		n := copy(f.header[f.filled:size], b)
+		f.filled += n
+		b = b[n:]
+		if f.filled < size {
+			return
+		}
+
+		typ := f.header[0]
+		length := binary.BigEndian.Uint32(f.header[size-4 : size])
+		if f.startup {
+			typ = 0
+			f.startup = false
+		}
+		f.onMessage(typ)
+		f.filled = 0
+		f.remaining = int(length) - 4
+	}
+}
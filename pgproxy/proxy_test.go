@@ -0,0 +1,146 @@
+package pgproxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-select-benchmark/pgreplay"
+)
+
+func startProxy(t *testing.T) (*Proxy, string) {
+	t.Helper()
+
+	server := pgreplay.NewServer(pgreplay.NewFixture())
+	if err := server.Listen("127.0.0.1:0"); err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	p := New(server.Addr().String())
+	if err := p.Listen("127.0.0.1:0"); err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+
+	port := p.Addr().(*net.TCPAddr).Port
+	return p, fmt.Sprintf("host=127.0.0.1 port=%d user=postgres dbname=order", port)
+}
+
+func TestProxyCountsTraffic(t *testing.T) {
+	p, dsn := startProxy(t)
+
+	for _, sslmode := range []string{"disable", "prefer"} {
+		t.Run(sslmode, func(t *testing.T) {
+			ctx := context.Background()
+			conn, err := pgx.Connect(ctx, dsn+" sslmode="+sslmode)
+			if err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+			defer conn.Close(ctx)
+
+			before := p.Stats()
+			if err := conn.Ping(ctx); err != nil {
+				t.Fatalf("ping failed: %v", err)
+			}
+			got := p.Stats().Sub(before)
+
+			// A Query answered by EmptyQueryResponse and ReadyForQuery.
+			want := Stats{
+				Queries:        1,
+				RoundTrips:     1,
+				ClientMessages: 1,
+				ClientBytes:    1 + 4 + int64(len("-- ping")) + 1,
+				ServerMessages: 2,
+				ServerBytes:    5 + 6,
+			}
+			if got != want {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestFramerSplitChunks(t *testing.T) {
+	stream := []byte{
+		0, 0, 0, 8, 0, 3, 0, 0, // StartupMessage without parameters
+		'Q', 0, 0, 0, 6, 'x', 0,
+		'S', 0, 0, 0, 4,
+	}
+
+	for size := 1; size <= len(stream); size++ {
+		var types []byte
+		f := &framer{startup: true, onMessage: func(typ byte) { types = append(types, typ) }}
+		for b := stream; len(b) > 0; {
+			n := min(size, len(b))
+			f.feed(b[:n])
+			b = b[n:]
+		}
+
+		if string(types) != "\x00QS" {
+			t.Errorf("chunk size %d: expected messages %q, got %q", size, "\x00QS", types)
+		}
+	}
+}
+
+func TestProxyProfileDelaysRoundTrips(t *testing.T) {
+	p, dsn := startProxy(t)
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, dsn+" sslmode=disable")
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	const rtt = 20 * time.Millisecond
+	p.SetProfile(Profile{RTT: rtt, Jitter: time.Millisecond})
+
+	start := time.Now()
+	for range 3 {
+		if err := conn.Ping(ctx); err != nil {
+			t.Fatalf("ping failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 3*rtt {
+		t.Errorf("expected 3 pings to take at least %s, took %s", 3*rtt, elapsed)
+	}
+
+	p.SetProfile(Profile{})
+	start = time.Now()
+	if err := conn.Ping(ctx); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= rtt {
+		t.Errorf("expected an undelayed ping to take less than %s, took %s", rtt, elapsed)
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want Profile
+	}{
+		{"lan", Profiles["lan"]},
+		{"2ms", Profile{RTT: 2 * time.Millisecond}},
+		{"2ms:200us:1000", Profile{RTT: 2 * time.Millisecond, Jitter: 200 * time.Microsecond, Bandwidth: 125_000_000}},
+	} {
+		got, err := ParseProfile(tt.in)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: expected %+v, got %+v", tt.in, tt.want, got)
+		}
+	}
+
+	for _, in := range []string{"", "fast", "2ms:-1ms", "2ms:0s:x", "1ms:1ms:1:1"} {
+		if _, err := ParseProfile(in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
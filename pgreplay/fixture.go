@@ -0,0 +1,249 @@
+package pgreplay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+// Result is the recorded outcome of one statement.
+type Result struct {
+	Fields     []pgconn.FieldDescription
+	Rows       [][][]byte
+	CommandTag string
+	// Copy marks the result of a COPY ... TO STDOUT, whose data CopyData
+	// holds as the server sent it instead of Rows.
+	Copy     bool
+	CopyData [][]byte
+
+	once sync.Once
+	wire []byte
+}
+
+// rowDescription returns the RowDescription message announcing r.
+func (r *Result) rowDescription() *pgproto3.RowDescription {
+	return rowDescription(r.Fields)
+}
+
+// messages returns the DataRow and CommandComplete messages of r, or its
+// COPY messages, encoded once so replaying a result is a single write.
+func (r *Result) messages() []byte {
+	r.once.Do(func() {
+		var err error
+		if r.Copy {
+			r.wire = r.copyMessages()
+		}
+		for _, row := range r.Rows {
+			if r.wire, err = (&pgproto3.DataRow{Values: row}).Encode(r.wire); err != nil {
+				panic("pgreplay: encode data row: " + err.Error())
+			}
+		}
+		if r.wire, err = (&pgproto3.CommandComplete{CommandTag: []byte(r.CommandTag)}).Encode(r.wire); err != nil {
+			panic("pgreplay: encode command complete: " + err.Error())
+		}
+	})
+	return r.wire
+}
+
+// copyMessages returns the CopyOutResponse, CopyData and CopyDone messages
+// of a COPY result. The response carries no column formats, which no
+// client reads; the overall format follows the binary signature.
+func (r *Result) copyMessages() []byte {
+	var format byte
+	if len(r.CopyData) > 0 && bytes.HasPrefix(r.CopyData[0], copySignature) {
+		format = 1
+	}
+
+	wire, err := (&pgproto3.CopyOutResponse{OverallFormat: format}).Encode(nil)
+	if err != nil {
+		panic("pgreplay: encode copy out response: " + err.Error())
+	}
+	for _, data := range r.CopyData {
+		if wire, err = (&pgproto3.CopyData{Data: data}).Encode(wire); err != nil {
+			panic("pgreplay: encode copy data: " + err.Error())
+		}
+	}
+	if wire, err = (&pgproto3.CopyDone{}).Encode(wire); err != nil {
+		panic("pgreplay: encode copy done: " + err.Error())
+	}
+	return wire
+}
+
+// copySignature starts every binary COPY stream.
+var copySignature = []byte("PGCOPY\n\xff\r\n\x00")
+
+// Description is the recorded outcome of describing a statement.
+type Description struct {
+	ParamOIDs []uint32
+	Fields    []pgconn.FieldDescription
+}
+
+func rowDescription(fields []pgconn.FieldDescription) *pgproto3.RowDescription {
+	rd := &pgproto3.RowDescription{Fields: make([]pgproto3.FieldDescription, len(fields))}
+	for i, f := range fields {
+		rd.Fields[i] = pgproto3.FieldDescription{
+			Name:                 []byte(f.Name),
+			TableOID:             f.TableOID,
+			TableAttributeNumber: f.TableAttributeNumber,
+			DataTypeOID:          f.DataTypeOID,
+			DataTypeSize:         f.DataTypeSize,
+			TypeModifier:         f.TypeModifier,
+			Format:               f.Format,
+		}
+	}
+	return rd
+}
+
+// Fixture holds everything the server needs to answer the queries it
+// recorded.
+type Fixture struct {
+	mu   sync.RWMutex
+	data fixtureData
+}
+
+type fixtureData struct {
+	// Params are the ParameterStatus values sent after startup.
+	Params map[string]string
+	// Simple maps a simple-protocol query string to its results.
+	Simple map[string][]*Result
+	// Describe maps a statement's SQL to its description.
+	Describe map[string]*Description
+	// Execute maps a bound portal, see executeKey, to its result.
+	Execute map[string]*Result
+}
+
+// NewFixture returns an empty fixture.
+func NewFixture() *Fixture {
+	return &Fixture{data: fixtureData{
+		Params:   map[string]string{},
+		Simple:   map[string][]*Result{},
+		Describe: map[string]*Description{},
+		Execute:  map[string]*Result{},
+	}}
+}
+
+// LoadFixture reads a fixture written by Save.
+func LoadFixture(path string) (*Fixture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fixture := NewFixture()
+	if err := gob.NewDecoder(f).Decode(&fixture.data); err != nil {
+		return nil, fmt.Errorf("pgreplay: decode %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// Save writes the fixture to path.
+func (f *Fixture) Save(path string) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := gob.NewEncoder(file).Encode(&f.data); err != nil {
+		file.Close()
+		return fmt.Errorf("pgreplay: encode %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// Len returns the number of recorded statements.
+func (f *Fixture) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.data.Simple) + len(f.data.Execute)
+}
+
+func (f *Fixture) params() map[string]string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return maps.Clone(f.data.Params)
+}
+
+func (f *Fixture) setParam(name, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data.Params[name] = value
+}
+
+func (f *Fixture) simple(sql string) ([]*Result, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	res, ok := f.data.Simple[sql]
+	return res, ok
+}
+
+func (f *Fixture) storeSimple(sql string, res []*Result) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data.Simple[sql] = res
+}
+
+func (f *Fixture) describe(sql string) (*Description, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	desc, ok := f.data.Describe[sql]
+	return desc, ok
+}
+
+func (f *Fixture) storeDescribe(sql string, desc *Description) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data.Describe[sql] = desc
+}
+
+func (f *Fixture) execute(key string) (*Result, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	res, ok := f.data.Execute[key]
+	return res, ok
+}
+
+func (f *Fixture) storeExecute(key string, res *Result) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data.Execute[key] = res
+}
+
+// executeKey identifies a bound portal by its SQL, its parameters and the
+// result formats the client asked for, since the same query returns
+// different bytes in text and binary format.
+func executeKey(sql string, paramFormats []int16, params [][]byte, resultFormats []int16) string {
+	var b strings.Builder
+	b.WriteString(sql)
+
+	writeFormats := func(formats []int16) {
+		b.WriteByte(0)
+		for _, format := range formats {
+			b.WriteByte(byte(format))
+		}
+	}
+
+	writeFormats(paramFormats)
+	for _, p := range params {
+		b.WriteByte(0)
+		if p == nil {
+			b.WriteString("\xff\xff\xff\xff")
+			continue
+		}
+		b.Write(binary.BigEndian.AppendUint32(nil, uint32(len(p))))
+		b.Write(p)
+	}
+	writeFormats(resultFormats)
+	return b.String()
+}
@@ -0,0 +1,558 @@
+// Package pgreplay implements a local server speaking the Postgres
+// frontend/backend protocol that replays recorded query results.
+//
+// Pointing a DSN at it lets lib/pq, pgx and GORM run their real protocol
+// decoders against byte-for-byte identical responses, with no server-side
+// work at all. A recording server forwards every statement it has not seen
+// to a real Postgres and stores the response.
+package pgreplay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+// defaultParams are sent after startup when the fixture recorded none.
+// pgx refuses the simple protocol without the last two.
+var defaultParams = map[string]string{
+	"server_version":              "17.0",
+	"server_encoding":             "UTF8",
+	"client_encoding":             "UTF8",
+	"DateStyle":                   "ISO, MDY",
+	"IntervalStyle":               "postgres",
+	"TimeZone":                    "UTC",
+	"integer_datetimes":           "on",
+	"standard_conforming_strings": "on",
+}
+
+// Server accepts Postgres connections and answers them from a Fixture.
+type Server struct {
+	fixture  *Fixture
+	upstream string
+
+	ln    net.Listener
+	pid   atomic.Uint32
+	wg    sync.WaitGroup
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+// NewServer returns a server that only replays fixture. Statements missing
+// from it fail with an error.
+func NewServer(fixture *Fixture) *Server {
+	return &Server{fixture: fixture, conns: map[net.Conn]struct{}{}}
+}
+
+// NewRecordingServer returns a server that forwards statements missing from
+// fixture to the Postgres at upstreamDSN and records their responses.
+func NewRecordingServer(fixture *Fixture, upstreamDSN string) *Server {
+	s := NewServer(fixture)
+	s.upstream = upstreamDSN
+	return s
+}
+
+// Listen starts accepting connections on addr, e.g. "127.0.0.1:0".
+func (s *Server) Listen(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.serve()
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.ln.Addr()
+}
+
+// Close stops the server and closes every open connection.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+
+			ss := &session{
+				server:   s,
+				conn:     conn,
+				backend:  pgproto3.NewBackend(conn, conn),
+				stmts:    map[string]statement{},
+				portals:  map[string]*portal{},
+				txStatus: 'I',
+			}
+			defer ss.close()
+			ss.run()
+		}()
+	}
+}
+
+type statement struct {
+	sql       string
+	paramOIDs []uint32
+}
+
+type portal struct {
+	stmt          statement
+	params        [][]byte
+	paramFormats  []int16
+	resultFormats []int16
+	res           *Result
+}
+
+// session serves one client connection.
+type session struct {
+	server   *Server
+	conn     net.Conn
+	backend  *pgproto3.Backend
+	upstream *pgconn.PgConn
+
+	stmts    map[string]statement
+	portals  map[string]*portal
+	txStatus byte
+	// failed is set after an error in the extended protocol; messages are
+	// then discarded until the next Sync.
+	failed bool
+}
+
+func (ss *session) close() {
+	if ss.upstream != nil {
+		ss.upstream.Close(context.Background())
+	}
+}
+
+func (ss *session) run() {
+	if err := ss.startup(); err != nil {
+		return
+	}
+
+	for {
+		msg, err := ss.backend.Receive()
+		if err != nil {
+			return
+		}
+
+		if _, ok := msg.(*pgproto3.Sync); ss.failed && !ok {
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *pgproto3.Query:
+			ss.query(msg.String)
+		case *pgproto3.Parse:
+			ss.stmts[msg.Name] = statement{sql: msg.Query, paramOIDs: slices.Clone(msg.ParameterOIDs)}
+			ss.backend.Send(&pgproto3.ParseComplete{})
+		case *pgproto3.Bind:
+			ss.bind(msg)
+		case *pgproto3.Describe:
+			ss.describe(msg)
+		case *pgproto3.Execute:
+			ss.execute(msg)
+		case *pgproto3.Close:
+			if msg.ObjectType == 'S' {
+				delete(ss.stmts, msg.Name)
+			} else {
+				delete(ss.portals, msg.Name)
+			}
+			ss.backend.Send(&pgproto3.CloseComplete{})
+		case *pgproto3.Sync:
+			ss.failed = false
+			ss.backend.Send(&pgproto3.ReadyForQuery{TxStatus: ss.txStatus})
+			err = ss.backend.Flush()
+		case *pgproto3.Flush:
+			err = ss.backend.Flush()
+		case *pgproto3.Terminate:
+			return
+		default:
+			ss.fail(fmt.Errorf("pgreplay: unsupported message %T", msg))
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (ss *session) startup() error {
+	for {
+		msg, err := ss.backend.ReceiveStartupMessage()
+		if err != nil {
+			return err
+		}
+
+		switch msg.(type) {
+		case *pgproto3.SSLRequest, *pgproto3.GSSEncRequest:
+			if _, err := ss.conn.Write([]byte{'N'}); err != nil {
+				return err
+			}
+			continue
+		case *pgproto3.StartupMessage:
+		default:
+			return fmt.Errorf("pgreplay: unsupported startup message %T", msg)
+		}
+
+		if ss.server.upstream != "" {
+			if ss.upstream, err = pgconn.Connect(context.Background(), ss.server.upstream); err != nil {
+				ss.backend.Send(errorResponse(err))
+				ss.backend.Flush()
+				return err
+			}
+			for name := range defaultParams {
+				if value := ss.upstream.ParameterStatus(name); value != "" {
+					ss.server.fixture.setParam(name, value)
+				}
+			}
+		}
+
+		params := ss.server.fixture.params()
+		if len(params) == 0 {
+			params = defaultParams
+		}
+
+		ss.backend.Send(&pgproto3.AuthenticationOk{})
+		for name, value := range params {
+			ss.backend.Send(&pgproto3.ParameterStatus{Name: name, Value: value})
+		}
+		ss.backend.Send(&pgproto3.BackendKeyData{ProcessID: ss.server.pid.Add(1)})
+		ss.backend.Send(&pgproto3.ReadyForQuery{TxStatus: ss.txStatus})
+		return ss.backend.Flush()
+	}
+}
+
+// query answers a simple-protocol query.
+func (ss *session) query(sql string) {
+	if isEmptyQuery(sql) {
+		ss.backend.Send(&pgproto3.EmptyQueryResponse{})
+		ss.backend.Send(&pgproto3.ReadyForQuery{TxStatus: ss.txStatus})
+		ss.backend.Flush()
+		return
+	}
+
+	results, err := ss.simpleResults(sql)
+	if err != nil {
+		ss.backend.Send(errorResponse(err))
+		ss.backend.Send(&pgproto3.ReadyForQuery{TxStatus: ss.txStatus})
+		ss.backend.Flush()
+		return
+	}
+
+	for _, res := range results {
+		if len(res.Fields) > 0 {
+			ss.backend.Send(res.rowDescription())
+		}
+		ss.writeResult(res)
+	}
+	ss.backend.Send(&pgproto3.ReadyForQuery{TxStatus: ss.txStatus})
+	ss.backend.Flush()
+}
+
+func (ss *session) bind(msg *pgproto3.Bind) {
+	stmt, ok := ss.stmts[msg.PreparedStatement]
+	if !ok {
+		ss.fail(fmt.Errorf("pgreplay: prepared statement %q does not exist", msg.PreparedStatement))
+		return
+	}
+
+	// The message is reused by the next Receive, so keep copies.
+	params := make([][]byte, len(msg.Parameters))
+	for i, p := range msg.Parameters {
+		if p != nil {
+			params[i] = slices.Clone(p)
+		}
+	}
+
+	ss.portals[msg.DestinationPortal] = &portal{
+		stmt:          stmt,
+		params:        params,
+		paramFormats:  slices.Clone(msg.ParameterFormatCodes),
+		resultFormats: slices.Clone(msg.ResultFormatCodes),
+	}
+	ss.backend.Send(&pgproto3.BindComplete{})
+}
+
+func (ss *session) describe(msg *pgproto3.Describe) {
+	if msg.ObjectType == 'P' {
+		p, ok := ss.portals[msg.Name]
+		if !ok {
+			ss.fail(fmt.Errorf("pgreplay: portal %q does not exist", msg.Name))
+			return
+		}
+
+		res, err := ss.portalResult(p)
+		if err != nil {
+			ss.fail(err)
+			return
+		}
+		if len(res.Fields) == 0 {
+			ss.backend.Send(&pgproto3.NoData{})
+			return
+		}
+		ss.backend.Send(res.rowDescription())
+		return
+	}
+
+	stmt, ok := ss.stmts[msg.Name]
+	if !ok {
+		ss.fail(fmt.Errorf("pgreplay: prepared statement %q does not exist", msg.Name))
+		return
+	}
+
+	desc, err := ss.description(stmt)
+	if err != nil {
+		ss.fail(err)
+		return
+	}
+
+	ss.backend.Send(&pgproto3.ParameterDescription{ParameterOIDs: desc.ParamOIDs})
+	if len(desc.Fields) == 0 {
+		ss.backend.Send(&pgproto3.NoData{})
+		return
+	}
+	ss.backend.Send(rowDescription(desc.Fields))
+}
+
+func (ss *session) execute(msg *pgproto3.Execute) {
+	p, ok := ss.portals[msg.Portal]
+	if !ok {
+		ss.fail(fmt.Errorf("pgreplay: portal %q does not exist", msg.Portal))
+		return
+	}
+
+	res, err := ss.portalResult(p)
+	if err != nil {
+		ss.fail(err)
+		return
+	}
+	ss.writeResult(res)
+}
+
+// writeResult writes the rows and command tag of res after any buffered
+// messages.
+func (ss *session) writeResult(res *Result) {
+	if err := ss.backend.Flush(); err != nil {
+		return
+	}
+	if _, err := ss.conn.Write(res.messages()); err != nil {
+		return
+	}
+	ss.trackTx(res.CommandTag)
+}
+
+// trackTx keeps the transaction status reported in ReadyForQuery in line
+// with the replayed commands; lib/pq checks it after BEGIN.
+func (ss *session) trackTx(tag string) {
+	switch tag {
+	case "BEGIN":
+		ss.txStatus = 'T'
+	case "COMMIT", "ROLLBACK":
+		ss.txStatus = 'I'
+	}
+}
+
+func (ss *session) fail(err error) {
+	ss.backend.Send(errorResponse(err))
+	ss.failed = true
+}
+
+func (ss *session) simpleResults(sql string) ([]*Result, error) {
+	if results, ok := ss.server.fixture.simple(sql); ok {
+		return results, nil
+	}
+	if ss.upstream == nil {
+		return nil, fmt.Errorf("pgreplay: no recording for query %q", truncate(sql))
+	}
+
+	if isCopyTo(sql) {
+		res, err := ss.copyResult(sql)
+		if err != nil {
+			return nil, err
+		}
+		ss.server.fixture.storeSimple(sql, []*Result{res})
+		return []*Result{res}, nil
+	}
+
+	mrr := ss.upstream.Exec(context.Background(), sql)
+	var results []*Result
+	for mrr.NextResult() {
+		res, err := readResult(mrr.ResultReader())
+		if err != nil {
+			mrr.Close()
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	if err := mrr.Close(); err != nil {
+		return nil, err
+	}
+
+	ss.server.fixture.storeSimple(sql, results)
+	return results, nil
+}
+
+// copyResult records a COPY ... TO STDOUT, which Exec cannot read.
+func (ss *session) copyResult(sql string) (*Result, error) {
+	res := &Result{Copy: true}
+	tag, err := ss.upstream.CopyTo(context.Background(), copyRecorder{res}, sql)
+	if err != nil {
+		return nil, err
+	}
+	res.CommandTag = tag.String()
+	return res, nil
+}
+
+// copyRecorder keeps a copy of every CopyData message pgconn writes.
+type copyRecorder struct{ res *Result }
+
+func (w copyRecorder) Write(p []byte) (int, error) {
+	w.res.CopyData = append(w.res.CopyData, slices.Clone(p))
+	return len(p), nil
+}
+
+func (ss *session) description(stmt statement) (*Description, error) {
+	if desc, ok := ss.server.fixture.describe(stmt.sql); ok {
+		return desc, nil
+	}
+	if ss.upstream == nil {
+		return nil, fmt.Errorf("pgreplay: no recorded description for %q", truncate(stmt.sql))
+	}
+
+	sd, err := ss.upstream.Prepare(context.Background(), "", stmt.sql, stmt.paramOIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := &Description{ParamOIDs: sd.ParamOIDs, Fields: sd.Fields}
+	ss.server.fixture.storeDescribe(stmt.sql, desc)
+	return desc, nil
+}
+
+func (ss *session) portalResult(p *portal) (*Result, error) {
+	if p.res != nil {
+		return p.res, nil
+	}
+
+	key := executeKey(p.stmt.sql, p.paramFormats, p.params, p.resultFormats)
+	if res, ok := ss.server.fixture.execute(key); ok {
+		p.res = res
+		return res, nil
+	}
+	if ss.upstream == nil {
+		return nil, fmt.Errorf("pgreplay: no recording for query %q with %d params", truncate(p.stmt.sql), len(p.params))
+	}
+
+	rr := ss.upstream.ExecParams(context.Background(), p.stmt.sql, p.params, p.stmt.paramOIDs, p.paramFormats, p.resultFormats)
+	res, err := readResult(rr)
+	if err != nil {
+		return nil, err
+	}
+
+	ss.server.fixture.storeExecute(key, res)
+	p.res = res
+	return res, nil
+}
+
+// readResult reads rr to the end. Unlike ResultReader.Read it keeps the
+// field descriptions of results with no rows.
+func readResult(rr *pgconn.ResultReader) (*Result, error) {
+	res := &Result{}
+	for rr.NextRow() {
+		values := rr.Values()
+		row := make([][]byte, len(values))
+		for i, v := range values {
+			if v != nil {
+				row[i] = slices.Clone(v)
+			}
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	res.Fields = slices.Clone(rr.FieldDescriptions())
+
+	tag, err := rr.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.CommandTag = tag.String()
+	return res, nil
+}
+
+func errorResponse(err error) *pgproto3.ErrorResponse {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return &pgproto3.ErrorResponse{
+			Severity: pgErr.Severity,
+			Code:     pgErr.Code,
+			Message:  pgErr.Message,
+			Detail:   pgErr.Detail,
+		}
+	}
+	return &pgproto3.ErrorResponse{Severity: "ERROR", Code: "XX000", Message: err.Error()}
+}
+
+// isEmptyQuery reports whether sql holds nothing but whitespace, line
+// comments and semicolons, which is how pgx and lib/pq ping.
+func isEmptyQuery(sql string) bool {
+	for line := range strings.Lines(sql) {
+		line, _, _ = strings.Cut(line, "--")
+		if strings.Trim(line, " \t\r\n;") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isCopyTo reports whether sql copies data out to the client.
+func isCopyTo(sql string) bool {
+	sql = strings.ToUpper(strings.TrimSpace(sql))
+	return strings.HasPrefix(sql, "COPY") && strings.Contains(sql, "TO STDOUT")
+}
+
+func truncate(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > 120 {
+		return sql[:120] + "..."
+	}
+	return sql
+}
@@ -0,0 +1,147 @@
+package pgreplay
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	_ "github.com/lib/pq"
+)
+
+func startServer(t *testing.T, fixture *Fixture) string {
+	t.Helper()
+
+	s := NewServer(fixture)
+	if err := s.Listen("127.0.0.1:0"); err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	addr := s.Addr().(*net.TCPAddr)
+	return fmt.Sprintf("host=127.0.0.1 port=%d user=postgres dbname=order sslmode=disable", addr.Port)
+}
+
+func TestReplaySimpleProtocol(t *testing.T) {
+	const query = "SELECT name FROM customers ORDER BY name"
+
+	fixture := NewFixture()
+	fixture.storeSimple(query, []*Result{{
+		Fields:     []pgconn.FieldDescription{{Name: "name", DataTypeOID: pgtype.TextOID, DataTypeSize: -1}},
+		Rows:       [][][]byte{{[]byte("Alice")}, {[]byte("Bob")}},
+		CommandTag: "SELECT 2",
+	}})
+
+	db, err := sql.Open("postgres", startServer(t, fixture))
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows failed: %v", err)
+	}
+	if fmt.Sprint(names) != "[Alice Bob]" {
+		t.Errorf("expected [Alice Bob], got %v", names)
+	}
+
+	if _, err := db.Query("SELECT 1"); err == nil {
+		t.Error("expected an error for a query that was never recorded")
+	}
+}
+
+func TestReplayExtendedProtocol(t *testing.T) {
+	const query = "SELECT id, name FROM customers WHERE id = $1"
+
+	fields := []pgconn.FieldDescription{
+		{Name: "id", DataTypeOID: pgtype.Int4OID, DataTypeSize: 4},
+		{Name: "name", DataTypeOID: pgtype.TextOID, DataTypeSize: -1},
+	}
+
+	// pgx sends int4 parameters and reads int4 results in binary, text in
+	// text.
+	fixture := NewFixture()
+	fixture.storeDescribe(query, &Description{ParamOIDs: []uint32{pgtype.Int4OID}, Fields: fields})
+	key := executeKey(query, []int16{1}, [][]byte{{0, 0, 0, 7}}, []int16{1, 0})
+	resultFields := []pgconn.FieldDescription{fields[0], fields[1]}
+	resultFields[0].Format = 1
+	fixture.storeExecute(key, &Result{
+		Fields:     resultFields,
+		Rows:       [][][]byte{{{0, 0, 0, 7}, []byte("Alice")}},
+		CommandTag: "SELECT 1",
+	})
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, startServer(t, fixture))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	// Run twice so the second query reuses pgx's cached statement.
+	for range 2 {
+		var (
+			id   int32
+			name string
+		)
+		if err := conn.QueryRow(ctx, query, int32(7)).Scan(&id, &name); err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		if id != 7 || name != "Alice" {
+			t.Errorf("expected (7, Alice), got (%d, %s)", id, name)
+		}
+	}
+
+	if err := conn.QueryRow(ctx, query, int32(8)).Scan(new(int32), new(string)); err == nil {
+		t.Error("expected an error for parameters that were never recorded")
+	}
+	if err := conn.Ping(ctx); err != nil {
+		t.Errorf("connection unusable after a replay error: %v", err)
+	}
+}
+
+func TestReplayCopyTo(t *testing.T) {
+	const query = "COPY (SELECT name FROM customers) TO STDOUT"
+
+	fixture := NewFixture()
+	fixture.storeSimple(query, []*Result{{
+		Copy:       true,
+		CopyData:   [][]byte{[]byte("Alice\n"), []byte("Bob\n")},
+		CommandTag: "COPY 2",
+	}})
+
+	ctx := context.Background()
+	conn, err := pgconn.Connect(ctx, startServer(t, fixture))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	var out strings.Builder
+	tag, err := conn.CopyTo(ctx, &out, query)
+	if err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+	if out.String() != "Alice\nBob\n" || tag.RowsAffected() != 2 {
+		t.Errorf("expected 2 rows of Alice and Bob, got %d rows of %q", tag.RowsAffected(), out.String())
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+)
+
+var pools = flag.String("pools", "", "pool configurations swept by BenchmarkPoolSizing, as comma-separated OPEN:IDLE:LIFETIME")
+
+// BenchmarkPoolSizing runs the parallel OneResult scenario under every
+// pool configuration in -pools, opening fresh pools for each, and reports
+// the connections opened and the time spent waiting for one per query.
+func BenchmarkPoolSizing(b *testing.B) {
+	if *pools == "" {
+		b.Skip("pass -pools to sweep pool configurations")
+	}
+	if env.DSN == "" {
+		b.Skip("BenchmarkPoolSizing opens its own pools and cannot run in replay or record mode")
+	}
+
+	var configs []contender.PoolConfig
+	for _, field := range strings.Split(*pools, ",") {
+		cfg, err := contender.ParsePoolConfig(strings.TrimSpace(field))
+		if err != nil {
+			b.Fatal(err)
+		}
+		configs = append(configs, cfg)
+	}
+
+	for _, cfg := range configs {
+		b.Run("pool="+cfg.String(), func(b *testing.B) {
+			poolEnv, err := openEnv(env.DSN, cfg)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer poolEnv.DB.Close()
+			defer poolEnv.Pool.Close()
+
+			// Contenders are built once per configuration, so that pools
+			// they own stay warm across b.N rounds like Env's do.
+			built := map[string]contender.Contender{}
+			defer func() {
+				for _, c := range built {
+					if closer, ok := c.(io.Closer); ok {
+						closer.Close()
+					}
+				}
+			}()
+
+			for _, p := range parseInts(b, *parallelism) {
+				for _, name := range contender.Names() {
+					b.Run(fmt.Sprintf("p=%d/%s", p, name), func(b *testing.B) {
+						c, ok := built[name]
+						if !ok {
+							var err error
+							c, err = contender.New(context.Background(), name, poolEnv)
+							if errors.Is(err, contender.ErrUnsupported) {
+								b.Skipf("%s: unsupported in this environment", name)
+							}
+							if err != nil {
+								b.Fatalf("%s: failed to initialize: %v", name, err)
+							}
+							built[name] = c
+						}
+						if _, ok := c.(contender.Serial); ok {
+							b.Skipf("%s is not safe for concurrent use", name)
+						}
+
+						b.SetParallelism(p)
+						before := readPoolStats(poolEnv, c)
+						b.ResetTimer()
+
+						benchmarkFetchOneParallel(b, c)
+
+						after := readPoolStats(poolEnv, c)
+						ops := float64(b.N)
+						b.ReportMetric(float64(after.opened-before.opened)/ops, "conns/op")
+						b.ReportMetric(float64(after.waits-before.waits)/ops, "waits/op")
+						b.ReportMetric(float64(after.waited-before.waited)/ops, "wait-ns/op")
+						b.ReportMetric(float64(after.acquired-before.acquired)/ops, "acquire-ns/op")
+					})
+				}
+			}
+		})
+	}
+}
+
+// poolStats is the connection pool activity visible to one contender,
+// summed over Env.DB, Env.Pool and any pool the contender owns.
+type poolStats struct {
+	opened int64
+	waits  int64
+	// waited is the time database/sql spent blocked waiting for a
+	// connection.
+	waited time.Duration
+	// acquired is the time pgxpool spent in every acquire, including the
+	// ones that found an idle connection. pgxpool v5.6 does not report the
+	// blocked part alone, so it is kept apart from waited.
+	acquired time.Duration
+}
+
+func readPoolStats(e contender.Env, c contender.Contender) poolStats {
+	var s poolStats
+
+	addDB := func(st sql.DBStats) {
+		// database/sql does not count opened connections, but every one
+		// is either still open or was closed by one of the limits.
+		s.opened += int64(st.OpenConnections) + st.MaxIdleClosed + st.MaxIdleTimeClosed + st.MaxLifetimeClosed
+		s.waits += st.WaitCount
+		s.waited += st.WaitDuration
+	}
+	addDB(e.DB.Stats())
+	if owner, ok := c.(contender.PoolOwner); ok {
+		addDB(owner.DBStats())
+	}
+
+	// pgxpool counts the acquires that had to wait for a connection, but
+	// only times all of them together.
+	st := e.Pool.Stat()
+	s.opened += st.NewConnsCount()
+	s.waits += st.EmptyAcquireCount()
+	s.acquired += st.AcquireDuration()
+
+	return s
+}
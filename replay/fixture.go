@@ -0,0 +1,108 @@
+package replay
+
+import (
+	"database/sql/driver"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+// Result is the recorded outcome of one query.
+type Result struct {
+	Columns   []string
+	TypeNames []string
+	Rows      [][]driver.Value
+}
+
+// Fixture maps a query and its arguments to the rows it returned.
+type Fixture struct {
+	mu      sync.RWMutex
+	results map[string]*Result
+}
+
+// NewFixture returns an empty fixture.
+func NewFixture() *Fixture {
+	return &Fixture{results: map[string]*Result{}}
+}
+
+// LoadFixture reads a fixture written by Save.
+func LoadFixture(path string) (*Fixture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fixture := NewFixture()
+	if err := gob.NewDecoder(f).Decode(&fixture.results); err != nil {
+		return nil, fmt.Errorf("replay: decode %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// Save writes the fixture to path.
+func (f *Fixture) Save(path string) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := gob.NewEncoder(file).Encode(f.results); err != nil {
+		file.Close()
+		return fmt.Errorf("replay: encode %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// Len returns the number of recorded queries.
+func (f *Fixture) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.results)
+}
+
+func (f *Fixture) lookup(query string, args []driver.NamedValue) (*Result, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	res, ok := f.results[key(query, args)]
+	if !ok {
+		return nil, fmt.Errorf("replay: no recording for query %q with %d args", strings.TrimSpace(query), len(args))
+	}
+	return res, nil
+}
+
+func (f *Fixture) store(query string, args []driver.NamedValue, res *Result) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[key(query, args)] = res
+}
+
+// key identifies a query by its text and argument values.
+func key(query string, args []driver.NamedValue) string {
+	var b strings.Builder
+	b.WriteString(query)
+	for _, arg := range args {
+		b.WriteByte(0)
+		switch v := arg.Value.(type) {
+		case int64:
+			b.WriteString(strconv.FormatInt(v, 10))
+		case string:
+			b.WriteString(v)
+		default:
+			fmt.Fprintf(&b, "%T:%v", v, v)
+		}
+	}
+	return b.String()
+}
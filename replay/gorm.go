@@ -0,0 +1,18 @@
+package replay
+
+import (
+	"database/sql"
+
+	"gorm.io/driver/postgres"
+)
+
+// Dialector is the GORM postgres dialector running on top of a replay
+// (or recording) *sql.DB instead of opening its own pgx connection.
+type Dialector struct {
+	postgres.Dialector
+}
+
+// NewDialector returns a GORM dialector that sends every query to db.
+func NewDialector(db *sql.DB) Dialector {
+	return Dialector{postgres.Dialector{Config: &postgres.Config{Conn: db}}}
+}
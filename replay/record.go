@@ -0,0 +1,177 @@
+package replay
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+)
+
+// Recorder wraps a real driver and records every query result read
+// through it into a Fixture.
+type Recorder struct {
+	fixture *Fixture
+	next    driver.Driver
+	dsn     string
+}
+
+// NewRecorder returns a connector that opens connections to dsn with next
+// and records their query results into fixture.
+func NewRecorder(next driver.Driver, dsn string, fixture *Fixture) *Recorder {
+	return &Recorder{fixture: fixture, next: next, dsn: dsn}
+}
+
+func (r *Recorder) Connect(context.Context) (driver.Conn, error) {
+	c, err := r.next.Open(r.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &recordConn{Conn: c, fixture: r.fixture}, nil
+}
+
+func (r *Recorder) Driver() driver.Driver {
+	return r.next
+}
+
+type recordConn struct {
+	driver.Conn
+	fixture *Fixture
+}
+
+func (c *recordConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return newRecordRows(rows, c.fixture, query, args), nil
+}
+
+func (c *recordConn) Prepare(query string) (driver.Stmt, error) {
+	s, err := c.Conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &recordStmt{Stmt: s, fixture: c.fixture, query: query}, nil
+}
+
+func (c *recordConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *recordConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+type recordStmt struct {
+	driver.Stmt
+	fixture *Fixture
+	query   string
+}
+
+func (s *recordStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, errors.New("replay: recorded statement does not support QueryContext")
+	}
+
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return newRecordRows(rows, s.fixture, s.query, args), nil
+}
+
+type recordRows struct {
+	driver.Rows
+	fixture *Fixture
+	query   string
+	args    []driver.NamedValue
+	res     *Result
+	done    bool
+}
+
+func newRecordRows(rows driver.Rows, fixture *Fixture, query string, args []driver.NamedValue) *recordRows {
+	columns := rows.Columns()
+	res := &Result{
+		Columns:   columns,
+		TypeNames: make([]string, len(columns)),
+	}
+	if typed, ok := rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		for i := range columns {
+			res.TypeNames[i] = typed.ColumnTypeDatabaseTypeName(i)
+		}
+	}
+
+	return &recordRows{
+		Rows:    rows,
+		fixture: fixture,
+		query:   query,
+		args:    slices.Clone(args),
+		res:     res,
+	}
+}
+
+func (r *recordRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == io.EOF {
+		r.finish()
+	}
+	if err != nil {
+		return err
+	}
+
+	row := make([]driver.Value, len(dest))
+	for i, v := range dest {
+		// Drivers may reuse their read buffer for byte slices.
+		if b, ok := v.([]byte); ok {
+			v = slices.Clone(b)
+		}
+		row[i] = v
+	}
+	r.res.Rows = append(r.res.Rows, row)
+	return nil
+}
+
+// Close drains any rows the caller did not read, so the fixture always
+// holds complete results.
+func (r *recordRows) Close() error {
+	if !r.done {
+		dest := make([]driver.Value, len(r.res.Columns))
+		for r.Next(dest) == nil {
+		}
+	}
+	return r.Rows.Close()
+}
+
+func (r *recordRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.res.TypeNames[index]
+}
+
+func (r *recordRows) ColumnTypeScanType(index int) reflect.Type {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return typed.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(any)).Elem()
+}
+
+func (r *recordRows) finish() {
+	if r.done {
+		return
+	}
+	r.done = true
+	r.fixture.store(r.query, r.args, r.res)
+}
@@ -0,0 +1,178 @@
+// Package replay implements a database/sql driver that serves query
+// results recorded from Postgres, so mapping libraries can be measured
+// without the network, the server, or lib/pq's decoding.
+//
+// Record a fixture by wrapping a real driver with NewRecorder, then open
+// it with sql.Open("replay", path).
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DriverName is the name the replay driver is registered under.
+const DriverName = "replay"
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// Driver replays fixtures. The data source name is the path of a fixture
+// written by Fixture.Save; each path is loaded once and shared by every
+// connection.
+type Driver struct {
+	mu       sync.Mutex
+	fixtures map[string]*Fixture
+}
+
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fixture, ok := d.fixtures[name]
+	if !ok {
+		var err error
+		if fixture, err = LoadFixture(name); err != nil {
+			return nil, err
+		}
+		if d.fixtures == nil {
+			d.fixtures = map[string]*Fixture{}
+		}
+		d.fixtures[name] = fixture
+	}
+	return &conn{fixture: fixture}, nil
+}
+
+// Connector returns a connector that replays fixture without reading it
+// from disk.
+func Connector(fixture *Fixture) driver.Connector {
+	return connector{fixture: fixture}
+}
+
+type connector struct {
+	fixture *Fixture
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{fixture: c.fixture}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return &Driver{}
+}
+
+var errReadOnly = errors.New("replay: only queries can be replayed")
+
+type conn struct {
+	fixture *Fixture
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	res, err := c.fixture.lookup(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{res: res}, nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error   { return nil }
+func (tx) Rollback() error { return nil }
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errReadOnly
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return s.QueryContext(context.Background(), named)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+type rows struct {
+	res *Result
+	pos int
+}
+
+func (r *rows) Columns() []string {
+	return r.res.Columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.res.Rows) {
+		return io.EOF
+	}
+	copy(dest, r.res.Rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.res.TypeNames[index]
+}
+
+// ColumnTypeScanType mirrors the scan types lib/pq reports, so mappers
+// that inspect them behave as they do against Postgres.
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	switch r.res.TypeNames[index] {
+	case "INT8":
+		return reflect.TypeOf(int64(0))
+	case "INT4":
+		return reflect.TypeOf(int32(0))
+	case "INT2":
+		return reflect.TypeOf(int16(0))
+	case "VARCHAR", "TEXT":
+		return reflect.TypeOf("")
+	case "BOOL":
+		return reflect.TypeOf(false)
+	case "DATE", "TIME", "TIMETZ", "TIMESTAMP", "TIMESTAMPTZ":
+		return reflect.TypeOf(time.Time{})
+	case "BYTEA":
+		return reflect.TypeOf([]byte(nil))
+	default:
+		return reflect.TypeOf(new(any)).Elem()
+	}
+}
@@ -0,0 +1,92 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// staticDriver answers every query with the same two rows.
+type staticDriver struct{}
+
+func (staticDriver) Open(string) (driver.Conn, error) { return staticConn{}, nil }
+
+type staticConn struct{ driver.Conn }
+
+func (staticConn) Close() error { return nil }
+
+func (staticConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &rows{res: &Result{
+		Columns:   []string{"id", "price", "created_at"},
+		TypeNames: []string{"INT4", "NUMERIC", "TIMESTAMP"},
+		Rows: [][]driver.Value{
+			{int64(1), []byte("9.99"), createdAt},
+			{int64(2), []byte("19.99"), nil},
+		},
+	}}, nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	const query = "SELECT id, price, created_at FROM items WHERE id > $1"
+
+	fixture := NewFixture()
+	recording := sql.OpenDB(NewRecorder(staticDriver{}, "", fixture))
+	defer recording.Close()
+
+	// Only read the first row: Close must still record both.
+	rows, err := recording.Query(query, 0)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	rows.Next()
+	rows.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.gob")
+	if err := fixture.Save(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	db, err := sql.Open(DriverName, path)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer db.Close()
+
+	rows, err = db.Query(query, 0)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("column types failed: %v", err)
+	}
+	if got := types[1].DatabaseTypeName(); got != "NUMERIC" {
+		t.Errorf("expected NUMERIC, got %s", got)
+	}
+
+	var prices []float64
+	for rows.Next() {
+		var (
+			id        int32
+			price     float64
+			createdAt *time.Time
+		)
+		if err := rows.Scan(&id, &price, &createdAt); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		prices = append(prices, price)
+	}
+	if len(prices) != 2 || prices[0] != 9.99 || prices[1] != 19.99 {
+		t.Errorf("expected prices [9.99 19.99], got %v", prices)
+	}
+
+	if _, err := db.Query(query, 1); err == nil {
+		t.Error("expected an error for arguments that were never recorded")
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// ceilingContender decodes the binary COPY stream by hand, the fastest a
+// library could map the join. Scenario summaries express every other
+// contender as overhead over it.
+const ceilingContender = "PgxCopy"
+
+// floorContender drains the join's rows through pgconn without decoding
+// them, the cost of the database and the wire alone. Scenario summaries
+// express every contender as a multiple of it.
+const floorContender = "PgxRaw"
+
+// result is the final, longest run of one contender in a scenario.
+type result struct {
+	nsPerOp     float64
+	allocsPerOp float64
+}
+
+// results collects a scenario's results by contender name.
+type results map[string]result
+
+// memSnapshot returns the allocation count record compares against.
+func memSnapshot() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.Mallocs
+}
+
+// record stores the run b just finished, which allocated since mallocs.
+// Every run overwrites the previous one, so the last run is kept.
+func (r results) record(b *testing.B, name string, mallocs uint64) {
+	n := float64(b.N)
+	r[name] = result{
+		nsPerOp:     float64(b.Elapsed().Nanoseconds()) / n,
+		allocsPerOp: float64(memSnapshot()-mallocs) / n,
+	}
+}
+
+// log writes a table of every result as a multiple of the floor, in time
+// and allocations, and with its time overhead over the ceiling contender.
+// Columns are left out when their reference did not run.
+func (r results) log(b *testing.B, names []string) {
+	floor, hasFloor := r[floorContender]
+	ceiling, hasCeiling := r[ceilingContender]
+	if !hasFloor && !hasCeiling || len(r) < 2 {
+		return
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "\n%-28s %14s %12s", "contender", "ns/op", "allocs/op")
+	if hasFloor {
+		fmt.Fprintf(&report, " %14s %14s", "time x floor", "allocs x floor")
+	}
+	if hasCeiling {
+		fmt.Fprintf(&report, " %14s", "vs "+ceilingContender)
+	}
+	report.WriteByte('\n')
+
+	for _, name := range names {
+		res, ok := r[name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&report, "%-28s %14.0f %12.0f", name, res.nsPerOp, res.allocsPerOp)
+		if hasFloor {
+			fmt.Fprintf(&report, " %13.2fx %14s", res.nsPerOp/floor.nsPerOp, multiple(res.allocsPerOp, floor.allocsPerOp))
+		}
+		if hasCeiling {
+			overhead := (res.nsPerOp/ceiling.nsPerOp - 1) * 100
+			fmt.Fprintf(&report, " %+13.1f%%", overhead)
+		}
+		report.WriteByte('\n')
+	}
+	if hasFloor {
+		fmt.Fprintf(&report, "floor: %s\n", floorContender)
+	}
+	b.Log(report.String())
+}
+
+// multiple formats v as a multiple of floor, or a dash when the floor did
+// not allocate.
+func multiple(v, floor float64) string {
+	if floor < 1 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1fx", v/floor)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+	"github.com/lucasHSantiago/go-select-benchmark/dataset"
+	"github.com/lucasHSantiago/go-select-benchmark/metrics"
+)
+
+var (
+	scaling       = flag.Bool("scaling", false, "run BenchmarkScaling; it reseeds the database for every point")
+	scalingOrders = flag.String("scaling-orders", "1,100,10000,100000,1000000", "order counts swept by BenchmarkScaling")
+	scalingItems  = flag.String("scaling-items", "1,5,50", "items per order swept by BenchmarkScaling")
+)
+
+// BenchmarkScaling runs FetchAll for every contender across a matrix of
+// dataset sizes, reports per-row metrics and fits ns/op = fixed + orders *
+// per-order + rows * per-row for each contender. The dataset found in
+// dataset_metadata is restored afterwards.
+func BenchmarkScaling(b *testing.B) {
+	if !*scaling {
+		b.Skip("pass -scaling to sweep dataset sizes")
+	}
+	if !liveDatabase() {
+		b.Skip("BenchmarkScaling reseeds the database and cannot run in a replay mode")
+	}
+
+	orderCounts := parseInts(b, *scalingOrders)
+	itemCounts := parseInts(b, *scalingItems)
+
+	original, err := dataset.ReadMetadata(b.Context(), env.DB)
+	if err != nil {
+		b.Fatalf("%v (run make migrateup)", err)
+	}
+	restore, err := original.Config()
+	if err != nil {
+		b.Fatalf("cannot restore the current dataset: %v", err)
+	}
+
+	conn, err := pgx.Connect(b.Context(), connStr)
+	if err != nil {
+		b.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close(b.Context())
+
+	defer func() {
+		if _, err := dataset.Seed(b.Context(), conn, restore); err != nil {
+			b.Errorf("failed to restore the original dataset: %v", err)
+		}
+	}()
+
+	// curves[name][point] is the ns/op of the last, longest run at that
+	// size. Points are keyed by orders and items, since different pairs
+	// can join to the same number of rows.
+	curves := map[string]map[scalingPoint]float64{}
+
+	for _, orders := range orderCounts {
+		for _, items := range itemCounts {
+			meta, err := dataset.Seed(b.Context(), conn, dataset.Config{
+				Orders: orders,
+				Items:  dataset.Fixed(items),
+				Seed:   1,
+			})
+			if err != nil {
+				b.Fatalf("failed to seed %d orders with %d items: %v", orders, items, err)
+			}
+
+			for _, name := range contender.Names() {
+				b.Run(fmt.Sprintf("orders=%d/items=%d/%s", orders, items, name), func(b *testing.B) {
+					c := getContender(b, name)
+					wire := wireSnapshot()
+					nsPerOp := benchmarkRows(b, c, meta.Shape)
+					reportWire(b, wire)
+
+					if curves[name] == nil {
+						curves[name] = map[scalingPoint]float64{}
+					}
+					curves[name][scalingPoint{orders: orders, items: items}] = nsPerOp
+				})
+			}
+		}
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "\n%-12s %14s %14s %14s\n", "contender", "fixed", "per order", "per row")
+	for _, name := range contender.Names() {
+		curve := curves[name]
+		if len(curve) == 0 {
+			continue
+		}
+		fmt.Fprintf(&report, "%-12s %s\n", name, fitScaling(curve))
+	}
+	b.Log(report.String())
+}
+
+// scalingPoint is one dataset of the matrix, seeded with a fixed number of
+// items per order.
+type scalingPoint struct {
+	orders, items int
+}
+
+func (p scalingPoint) rows() int {
+	return p.orders * p.items
+}
+
+// fitScaling fits ns/op = fixed + orders * per-order + rows * per-row
+// through curve, weighing every point by its relative error, and formats
+// the coefficients as report columns. With a single items per order,
+// orders and rows move together and only the per-row cost is fitted.
+func fitScaling(curve map[scalingPoint]float64) string {
+	var (
+		both, rowsOnly [][]float64
+		ys             []float64
+	)
+	for p, ns := range curve {
+		both = append(both, []float64{float64(p.orders), float64(p.rows())})
+		rowsOnly = append(rowsOnly, []float64{float64(p.rows())})
+		ys = append(ys, ns)
+	}
+
+	if c, ok := metrics.FitRelative(both, ys); ok {
+		return fmt.Sprintf("%14s %14s %14s", time.Duration(c[0]), fmt.Sprintf("%.1fns", c[1]), fmt.Sprintf("%.1fns", c[2]))
+	}
+	if c, ok := metrics.FitRelative(rowsOnly, ys); ok {
+		return fmt.Sprintf("%14s %14s %14s", time.Duration(c[0]), "-", fmt.Sprintf("%.1fns", c[1]))
+	}
+	return fmt.Sprintf("%14s %14s %14s", "-", "-", "-")
+}
+
+// benchmarkRows runs FetchAll b.N times, reports ns, bytes and allocations
+// per joined row plus rows/s, and returns ns/op.
+func benchmarkRows(b *testing.B, c contender.Contender, shape dataset.Shape) float64 {
+	skipBounded(b, c, shape)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+
+	for range b.N {
+		orders, err := c.FetchAll(b.Context())
+		if err != nil {
+			b.Fatalf("query failed: %v", err)
+		}
+
+		checkOrders(b, orders, shape)
+	}
+
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+
+	elapsed := float64(b.Elapsed().Nanoseconds())
+	rows := float64(b.N) * float64(shape.OrderItems)
+	b.ReportMetric(elapsed/rows, "ns/row")
+	b.ReportMetric(float64(after.TotalAlloc-before.TotalAlloc)/rows, "B/row")
+	b.ReportMetric(float64(after.Mallocs-before.Mallocs)/rows, "allocs/row")
+	b.ReportMetric(rows/(elapsed/1e9), "rows/s")
+
+	return elapsed / float64(b.N)
+}
+
+// liveDatabase reports whether the contenders talk to the Postgres at
+// connStr rather than to a replay.
+func liveDatabase() bool {
+	return *replayPath == "" && *recordPath == "" && *wireReplayPath == "" && *wireRecordPath == ""
+}
+
+func parseInts(b *testing.B, list string) []int {
+	var ints []int
+	for _, field := range strings.Split(list, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			b.Fatalf("invalid number %q in %q", field, list)
+		}
+		ints = append(ints, n)
+	}
+	return ints
+}
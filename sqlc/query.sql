@@ -0,0 +1,41 @@
+-- Queries for the sqlc contenders. Regenerate .gen/sqlc with `make sqlc`.
+
+-- name: ListOrdersWithItems :many
+SELECT orders.id,
+    orders.customer_name,
+    orders.created_at,
+    order_items.id AS order_item_id,
+    order_items.order_id,
+    order_items.product_name,
+    order_items.price,
+    order_items.quantity
+FROM orders
+    INNER JOIN order_items ON orders.id = order_items.order_id
+ORDER BY orders.id;
+
+-- name: GetOrderWithItems :many
+SELECT orders.id,
+    orders.customer_name,
+    orders.created_at,
+    order_items.id AS order_item_id,
+    order_items.order_id,
+    order_items.product_name,
+    order_items.price,
+    order_items.quantity
+FROM orders
+    INNER JOIN order_items ON orders.id = order_items.order_id
+WHERE orders.id = $1
+ORDER BY orders.id;
+
+-- name: ListOrdersWithItemsEmbed :many
+SELECT sqlc.embed(orders), sqlc.embed(order_items)
+FROM orders
+    INNER JOIN order_items ON orders.id = order_items.order_id
+ORDER BY orders.id;
+
+-- name: GetOrderWithItemsEmbed :many
+SELECT sqlc.embed(orders), sqlc.embed(order_items)
+FROM orders
+    INNER JOIN order_items ON orders.id = order_items.order_id
+WHERE orders.id = $1
+ORDER BY orders.id;
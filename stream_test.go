@@ -0,0 +1,147 @@
+package main
+
+import (
+	"runtime"
+	rtmetrics "runtime/metrics"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-select-benchmark/contender"
+)
+
+// BenchmarkStream compares consuming every order one at a time, discarding
+// each, with FetchAll materializing them all, and reports the peak heap of
+// both next to the allocations. Contenders that do not implement
+// contender.Streamer are listed as unsupported at the end.
+func BenchmarkStream(b *testing.B) {
+	b.Run("Collect", func(b *testing.B) {
+		for _, name := range contender.Names() {
+			b.Run(name, func(b *testing.B) {
+				c := getContender(b, name)
+				skipBounded(b, c, datasetShape(b))
+
+				heap := sampleHeap()
+				wire := wireSnapshot()
+				b.ResetTimer()
+				benchmarkFetchAll(b, c)
+				b.StopTimer()
+				b.ReportMetric(float64(heap.stop()), "peak-heap-B")
+				reportWire(b, wire)
+			})
+		}
+	})
+
+	var unsupported []string
+	b.Run("Stream", func(b *testing.B) {
+		for _, name := range contender.Names() {
+			b.Run(name, func(b *testing.B) {
+				c := getContender(b, name)
+				s, ok := c.(contender.Streamer)
+				if !ok {
+					unsupported = append(unsupported, name)
+					b.Skipf("%s cannot stream", name)
+				}
+
+				heap := sampleHeap()
+				wire := wireSnapshot()
+				b.ResetTimer()
+				benchmarkStream(b, s)
+				b.StopTimer()
+				b.ReportMetric(float64(heap.stop()), "peak-heap-B")
+				reportWire(b, wire)
+			})
+		}
+	})
+
+	if len(unsupported) > 0 {
+		b.Logf("streaming unsupported: %s", strings.Join(unsupported, ", "))
+	}
+}
+
+func benchmarkStream(b *testing.B, s contender.Streamer) {
+	shape := datasetShape(b)
+	b.ReportAllocs()
+
+	for range b.N {
+		var (
+			orders, items int
+			err           error
+		)
+		for order, e := range s.Stream(b.Context()) {
+			if e != nil {
+				err = e
+				break
+			}
+			orders++
+			items += len(order.Itens)
+		}
+		if err != nil {
+			b.Fatalf("stream failed: %v", err)
+		}
+
+		if orders != shape.OrdersWithItems {
+			b.Fatalf("expected %d results, got %d", shape.OrdersWithItems, orders)
+		}
+		if items != shape.OrderItems {
+			b.Fatalf("expected %d itens, got %d", shape.OrderItems, items)
+		}
+	}
+}
+
+// heapSampler polls the size of the heap's objects from a goroutine and
+// keeps the largest size seen above the size at start. Polling misses
+// short spikes, so the peak is a lower bound.
+type heapSampler struct {
+	base, peak    uint64
+	done, stopped chan struct{}
+}
+
+const heapObjects = "/memory/classes/heap/objects:bytes"
+
+// sampleHeap collects garbage, so the baseline holds only live objects,
+// and starts polling.
+func sampleHeap() *heapSampler {
+	runtime.GC()
+
+	h := &heapSampler{base: heapBytes(), done: make(chan struct{}), stopped: make(chan struct{})}
+	go h.run()
+	return h
+}
+
+func (h *heapSampler) run() {
+	defer close(h.stopped)
+
+	ticker := time.NewTicker(100 * time.Microsecond)
+	defer ticker.Stop()
+	for {
+		h.sample()
+		select {
+		case <-h.done:
+			h.sample()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *heapSampler) sample() {
+	h.peak = max(h.peak, heapBytes())
+}
+
+// stop stops polling and returns the peak above the baseline in bytes.
+func (h *heapSampler) stop() uint64 {
+	close(h.done)
+	<-h.stopped
+
+	if h.peak < h.base {
+		return 0
+	}
+	return h.peak - h.base
+}
+
+func heapBytes() uint64 {
+	sample := []rtmetrics.Sample{{Name: heapObjects}}
+	rtmetrics.Read(sample)
+	return sample[0].Value.Uint64()
+}